@@ -0,0 +1,219 @@
+package vte
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+)
+
+// Appearance represents appearance of the desktop, as configured in
+// [gtk.Settings].
+type Appearance struct {
+	// Dark reports whether dark theme is preferred.
+	Dark bool
+
+	// HighContrast reports whether high contrast theme is used.
+	HighContrast bool
+}
+
+// GetAppearance returns appearance of the desktop from settings.
+//
+// Dark theme is preferred if "gtk-application-prefer-dark-theme" is set, or
+// if "gtk-theme-name" is a dark variant of the theme, e.g. "Adwaita-dark".
+// High contrast theme is detected by "gtk-theme-name" as well, e.g.
+// "HighContrast" or "HighContrastInverse".
+func GetAppearance(settings *gtk.Settings) (Appearance, error) {
+	var appearance Appearance
+
+	preferDark, err := settings.GetProperty("gtk-application-prefer-dark-theme")
+	if err != nil {
+		return appearance, err
+	}
+
+	themeName, err := settings.GetProperty("gtk-theme-name")
+	if err != nil {
+		return appearance, err
+	}
+
+	dark, _ := preferDark.(bool)
+	name, _ := themeName.(string)
+	name = strings.ToLower(name)
+
+	appearance.HighContrast = strings.HasPrefix(name, "highcontrast")
+	appearance.Dark = dark ||
+		strings.HasSuffix(name, "-dark") ||
+		strings.HasSuffix(name, ":dark") ||
+		name == "highcontrastinverse"
+
+	return appearance, nil
+}
+
+// AppearanceThemes is a set of color schemes for different appearances of the
+// desktop. Light and Dark are required, high contrast schemes fall back to
+// Light and Dark respectively.
+type AppearanceThemes struct {
+	Light             *theme.Theme
+	Dark              *theme.Theme
+	HighContrastLight *theme.Theme
+	HighContrastDark  *theme.Theme
+
+	// OnError is called if the color scheme cannot be applied after the
+	// appearance has changed. The previous color scheme remains applied. If
+	// OnError is nil, such errors are ignored.
+	OnError func(err error)
+}
+
+// ForAppearance returns the color scheme for appearance a.
+func (themes *AppearanceThemes) ForAppearance(a Appearance) *theme.Theme {
+	switch {
+	case a.HighContrast && a.Dark && themes.HighContrastDark != nil:
+		return themes.HighContrastDark
+	case a.HighContrast && !a.Dark && themes.HighContrastLight != nil:
+		return themes.HighContrastLight
+	case a.Dark:
+		return themes.Dark
+	default:
+		return themes.Light
+	}
+}
+
+// validate checks that the required color schemes are present and all color
+// schemes are valid.
+func (themes *AppearanceThemes) validate() error {
+	if themes.Light == nil || themes.Dark == nil {
+		return errors.New("vte: light and dark themes must not be nil")
+	}
+
+	for _, th := range []*theme.Theme{
+		themes.Light,
+		themes.Dark,
+		themes.HighContrastLight,
+		themes.HighContrastDark,
+	} {
+		if th == nil {
+			continue
+		}
+		if err := th.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AppearanceWatcher switches color scheme of the terminal when appearance of
+// the desktop changes. It is created with [Terminal.WatchAppearance].
+type AppearanceWatcher struct {
+	term     *Terminal
+	settings *gtk.Settings
+	themes   AppearanceThemes
+
+	appearance Appearance
+	current    *theme.Theme
+	handles    []glib.SignalHandle
+	destroy    glib.SignalHandle
+	stopped    bool
+}
+
+// WatchAppearance applies the color scheme from themes that matches current
+// appearance of the desktop, and switches it whenever the appearance changes,
+// i.e. when "gtk-application-prefer-dark-theme" or "gtk-theme-name" of the
+// default [gtk.Settings] changes.
+//
+// The color scheme is applied with [Terminal.ApplyTheme]. Watching stops
+// when the terminal is destroyed, or when [AppearanceWatcher.Stop] is called.
+// The terminal has at most one watcher: the previous one is stopped once the
+// new one is set up successfully.
+func (t *Terminal) WatchAppearance(themes AppearanceThemes) (*AppearanceWatcher, error) {
+	if err := themes.validate(); err != nil {
+		return nil, err
+	}
+
+	settings, err := gtk.SettingsGetDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &AppearanceWatcher{
+		term:     t,
+		settings: settings,
+		themes:   themes,
+	}
+
+	if err := w.update(); err != nil {
+		return nil, err
+	}
+
+	if prev, ok := t.getData(terminalDataAppearance).(*AppearanceWatcher); ok {
+		prev.Stop()
+	}
+
+	for _, prop := range []string{
+		"gtk-application-prefer-dark-theme",
+		"gtk-theme-name",
+	} {
+		w.handles = append(w.handles, settings.Connect("notify::"+prop, func() {
+			if err := w.update(); err != nil && themes.OnError != nil {
+				themes.OnError(err)
+			}
+		}))
+	}
+
+	w.destroy = t.Connect("destroy", w.Stop)
+	t.setData(terminalDataAppearance, w)
+
+	return w, nil
+}
+
+// update applies the color scheme for the current appearance, if it has
+// changed.
+func (w *AppearanceWatcher) update() error {
+	appearance, err := GetAppearance(w.settings)
+	if err != nil {
+		return err
+	}
+
+	th := w.themes.ForAppearance(appearance)
+	if th != w.current {
+		if err := w.term.ApplyTheme(th); err != nil {
+			return fmt.Errorf("vte: apply theme %q: %w", th.Name, err)
+		}
+	}
+
+	w.appearance = appearance
+	w.current = th
+	return nil
+}
+
+// Appearance returns the appearance of the desktop the current color scheme
+// is chosen for.
+func (w *AppearanceWatcher) Appearance() Appearance {
+	return w.appearance
+}
+
+// Theme returns the color scheme currently applied to the terminal.
+func (w *AppearanceWatcher) Theme() *theme.Theme {
+	return w.current
+}
+
+// Stop stops watching appearance of the desktop. The current color scheme
+// remains applied.
+func (w *AppearanceWatcher) Stop() {
+	if w.stopped {
+		return
+	}
+	w.stopped = true
+
+	for _, handle := range w.handles {
+		w.settings.HandlerDisconnect(handle)
+	}
+	w.term.HandlerDisconnect(w.destroy)
+
+	if w.term.getData(terminalDataAppearance) == w {
+		w.term.setData(terminalDataAppearance, nil)
+	}
+}
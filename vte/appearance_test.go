@@ -0,0 +1,146 @@
+package vte_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gotk3/gotk3/gtk"
+	"github.com/shelepuginivan/gotk3-vte/vte"
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTheme(name string, c color.NRGBA) *theme.Theme {
+	palette := make([]color.NRGBA, 16)
+	for i := range palette {
+		palette[i] = c
+	}
+
+	return &theme.Theme{
+		Name:       name,
+		Foreground: c,
+		Background: c,
+		Palette:    palette,
+	}
+}
+
+func TestTerminal_WatchAppearance(t *testing.T) {
+	gtk.Init(nil)
+
+	settings, err := gtk.SettingsGetDefault()
+	assert.NoError(t, err)
+
+	themeName, err := settings.GetProperty("gtk-theme-name")
+	assert.NoError(t, err)
+
+	defer func() {
+		settings.SetProperty("gtk-application-prefer-dark-theme", false)
+		settings.SetProperty("gtk-theme-name", themeName)
+	}()
+
+	assert.NoError(t, settings.SetProperty("gtk-application-prefer-dark-theme", false))
+	assert.NoError(t, settings.SetProperty("gtk-theme-name", "Adwaita"))
+
+	var (
+		term   = newTerm(t)
+		light  = testTheme("light", color.NRGBA{0xff, 0xff, 0xff, 0xff})
+		dark   = testTheme("dark", color.NRGBA{0x00, 0x00, 0x00, 0xff})
+		hcDark = testTheme("high contrast dark", color.NRGBA{0x00, 0x00, 0xff, 0xff})
+	)
+
+	w, err := term.WatchAppearance(vte.AppearanceThemes{
+		Light:            light,
+		Dark:             dark,
+		HighContrastDark: hcDark,
+	})
+	assert.NoError(t, err)
+	defer w.Stop()
+
+	assert.Equal(t, vte.Appearance{}, w.Appearance())
+	assert.Equal(t, light, w.Theme())
+	assert.Equal(t, vte.RGB(0xff, 0xff, 0xff), term.GetPalette()[1])
+
+	assert.NoError(t, settings.SetProperty("gtk-application-prefer-dark-theme", true))
+	assert.Equal(t, vte.Appearance{Dark: true}, w.Appearance())
+	assert.Equal(t, dark, w.Theme())
+	assert.Equal(t, vte.RGB(0x00, 0x00, 0x00), term.GetPalette()[1])
+
+	assert.NoError(t, settings.SetProperty("gtk-application-prefer-dark-theme", false))
+	assert.Equal(t, light, w.Theme())
+
+	assert.NoError(t, settings.SetProperty("gtk-theme-name", "Adwaita-dark"))
+	assert.Equal(t, vte.Appearance{Dark: true}, w.Appearance())
+	assert.Equal(t, dark, w.Theme())
+
+	assert.NoError(t, settings.SetProperty("gtk-theme-name", "HighContrastInverse"))
+	assert.Equal(t, vte.Appearance{Dark: true, HighContrast: true}, w.Appearance())
+	assert.Equal(t, hcDark, w.Theme())
+
+	// High contrast light theme falls back to the light theme.
+	assert.NoError(t, settings.SetProperty("gtk-theme-name", "HighContrast"))
+	assert.Equal(t, vte.Appearance{HighContrast: true}, w.Appearance())
+	assert.Equal(t, light, w.Theme())
+
+	w.Stop()
+
+	assert.NoError(t, settings.SetProperty("gtk-application-prefer-dark-theme", true))
+	assert.Equal(t, light, w.Theme())
+	assert.Equal(t, vte.RGB(0xff, 0xff, 0xff), term.GetPalette()[1])
+
+	t.Run("Error", func(t *testing.T) {
+		assert.NoError(t, settings.SetProperty("gtk-application-prefer-dark-theme", false))
+		assert.NoError(t, settings.SetProperty("gtk-theme-name", "Adwaita"))
+
+		var (
+			broken = testTheme("broken", color.NRGBA{0x00, 0x00, 0x00, 0xff})
+			errs   []error
+		)
+
+		w, err := term.WatchAppearance(vte.AppearanceThemes{
+			Light: light,
+			Dark:  broken,
+			OnError: func(err error) {
+				errs = append(errs, err)
+			},
+		})
+		assert.NoError(t, err)
+		defer w.Stop()
+
+		// Themes are validated when watching starts, so break it afterwards.
+		broken.Palette = broken.Palette[:3]
+
+		assert.NoError(t, settings.SetProperty("gtk-application-prefer-dark-theme", true))
+		assert.Len(t, errs, 1)
+		assert.Equal(t, vte.Appearance{}, w.Appearance())
+		assert.Equal(t, light, w.Theme())
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		assert.NoError(t, settings.SetProperty("gtk-application-prefer-dark-theme", false))
+		assert.NoError(t, settings.SetProperty("gtk-theme-name", "Adwaita"))
+
+		first, err := term.WatchAppearance(vte.AppearanceThemes{Light: light, Dark: dark})
+		assert.NoError(t, err)
+
+		second, err := term.WatchAppearance(vte.AppearanceThemes{Light: light, Dark: hcDark})
+		assert.NoError(t, err)
+		defer second.Stop()
+
+		// The first watcher is stopped, so only the second one switches.
+		assert.NoError(t, settings.SetProperty("gtk-application-prefer-dark-theme", true))
+		assert.Equal(t, light, first.Theme())
+		assert.Equal(t, hcDark, second.Theme())
+		assert.Equal(t, vte.RGB(0x00, 0x00, 0xff), term.GetPalette()[1])
+	})
+
+	t.Run("Invalid themes", func(t *testing.T) {
+		_, err := term.WatchAppearance(vte.AppearanceThemes{Light: light})
+		assert.Error(t, err)
+
+		_, err = term.WatchAppearance(vte.AppearanceThemes{
+			Light: light,
+			Dark:  &theme.Theme{Palette: make([]color.NRGBA, 3)},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,16 @@
+package vte
+
+// #include <vte/vte.h>
+import "C"
+
+// PropertyFlags is a bitfield type that represents flags of the termprop.
+type PropertyFlags int
+
+const (
+	// No flags, default behaviour.
+	PROPERTY_FLAG_NONE PropertyFlags = C.VTE_PROPERTY_FLAG_NONE
+
+	// Termprop value is only available during emission of the "termprop-changed"
+	// signal, see [Terminal.ConnectTermPropChanged].
+	PROPERTY_FLAG_EPHEMERAL PropertyFlags = C.VTE_PROPERTY_FLAG_EPHEMERAL
+)
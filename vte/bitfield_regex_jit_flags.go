@@ -0,0 +1,14 @@
+package vte
+
+// RegexJITFlags is a bitfield type that represents PCRE2 JIT compilation
+// flags.
+//
+// See man:pcre2jit(3) for more information about every flag.
+type RegexJITFlags uint
+
+const (
+	REGEX_JIT_FLAGS_COMPLETE     RegexJITFlags = 0x00000001
+	REGEX_JIT_FLAGS_PARTIAL_SOFT RegexJITFlags = 0x00000002
+	REGEX_JIT_FLAGS_PARTIAL_HARD RegexJITFlags = 0x00000004
+	REGEX_JIT_FLAGS_INVALID_UTF  RegexJITFlags = 0x00000100
+)
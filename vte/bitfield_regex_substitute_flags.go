@@ -0,0 +1,18 @@
+package vte
+
+// RegexSubstituteFlags is a bitfield type that represents PCRE2 substitution
+// flags. [RegexMatchFlags] can be used as substitution flags too.
+//
+// See man:pcre2api(3) for more information about every flag.
+type RegexSubstituteFlags uint
+
+const (
+	REGEX_SUBSTITUTE_FLAGS_GLOBAL           RegexSubstituteFlags = 0x00000100
+	REGEX_SUBSTITUTE_FLAGS_EXTENDED         RegexSubstituteFlags = 0x00000200
+	REGEX_SUBSTITUTE_FLAGS_UNSET_EMPTY      RegexSubstituteFlags = 0x00000400
+	REGEX_SUBSTITUTE_FLAGS_UNKNOWN_UNSET    RegexSubstituteFlags = 0x00000800
+	REGEX_SUBSTITUTE_FLAGS_OVERFLOW_LENGTH  RegexSubstituteFlags = 0x00001000
+	REGEX_SUBSTITUTE_FLAGS_LITERAL          RegexSubstituteFlags = 0x00008000
+	REGEX_SUBSTITUTE_FLAGS_MATCHED          RegexSubstituteFlags = 0x00010000
+	REGEX_SUBSTITUTE_FLAGS_REPLACEMENT_ONLY RegexSubstituteFlags = 0x00020000
+)
@@ -0,0 +1,75 @@
+package vte
+
+// #include <glib.h>
+// #include "child_setup.go.h"
+import "C"
+import "os"
+
+// Rlimit represents a resource limit of the command, see man:setrlimit(2).
+type Rlimit struct {
+	// Resource to limit, e.g. [syscall.RLIMIT_NOFILE].
+	Resource int
+
+	// Soft limit.
+	Cur uint64
+
+	// Hard limit.
+	Max uint64
+}
+
+// ChildSetup represents setup of the command performed after fork and before
+// exec. Go code cannot run in the child process at that point, so setup is
+// described declaratively and carried out in C.
+//
+// If any step of the setup fails, the child process writes a message to its
+// stderr and exits with code 127.
+type ChildSetup struct {
+	// Whether to set file mode creation mask to Umask.
+	SetUmask bool
+
+	// File mode creation mask of the command, see man:umask(2).
+	Umask os.FileMode
+
+	// Resource limits of the command, applied in order.
+	Rlimits []Rlimit
+
+	// Increment of the command nice value, see man:nice(2). Zero leaves the
+	// nice value unchanged.
+	Nice int
+
+	// Whether to unblock all signals and reset their dispositions to default.
+	ResetSignalMask bool
+
+	// Whether to put the command in a new process group. The command leads its
+	// own process group anyway unless [PTY_NO_SESSION] is used.
+	NewProcessGroup bool
+}
+
+// cChildSetup returns setup in the form of C structure that is consumed by
+// the child setup function. If setup is nil, nil is returned. The caller is
+// responsible for freeing the structure with g_free.
+func cChildSetup(setup *ChildSetup) *C.VteGoChildSetup {
+	if setup == nil {
+		return nil
+	}
+
+	data := C.childSetupNew(C.int(len(setup.Rlimits)))
+
+	data.set_umask = gboolean(setup.SetUmask)
+	data.umask = C.mode_t(setup.Umask.Perm())
+	data.nice = C.int(setup.Nice)
+	data.reset_signal_mask = gboolean(setup.ResetSignalMask)
+	data.new_process_group = gboolean(setup.NewProcessGroup)
+
+	for i, rlimit := range setup.Rlimits {
+		C.childSetupSetRlimit(
+			data,
+			C.int(i),
+			C.int(rlimit.Resource),
+			C.rlim_t(rlimit.Cur),
+			C.rlim_t(rlimit.Max),
+		)
+	}
+
+	return data
+}
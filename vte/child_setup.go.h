@@ -0,0 +1,117 @@
+#include <errno.h>
+#include <stdint.h>
+#include <signal.h>
+#include <string.h>
+#include <sys/resource.h>
+#include <sys/stat.h>
+#include <unistd.h>
+
+#include <glib.h>
+
+typedef struct {
+    int resource;
+    rlim_t cur;
+    rlim_t max;
+} VteGoRlimit;
+
+typedef struct {
+    gboolean set_umask;
+    mode_t umask;
+    int nice;
+    gboolean reset_signal_mask;
+    gboolean new_process_group;
+    int n_rlimits;
+    VteGoRlimit rlimits[];
+} VteGoChildSetup;
+
+static VteGoChildSetup *childSetupNew(int n_rlimits) {
+    VteGoChildSetup *setup = g_malloc0(sizeof(VteGoChildSetup) + n_rlimits * sizeof(VteGoRlimit));
+    setup->n_rlimits = n_rlimits;
+    return setup;
+}
+
+static void childSetupSetRlimit(VteGoChildSetup *setup, int i, int resource, rlim_t cur, rlim_t max) {
+    setup->rlimits[i].resource = resource;
+    setup->rlimits[i].cur = cur;
+    setup->rlimits[i].max = max;
+}
+
+// Runs in the child between fork and exec, only async-signal-safe functions
+// may be called here.
+static void childSetupFail(const char *msg) {
+    static const char prefix[] = "vte: child setup failed: ";
+
+    ssize_t n = write(STDERR_FILENO, prefix, sizeof(prefix) - 1);
+    n = write(STDERR_FILENO, msg, strlen(msg));
+    n = write(STDERR_FILENO, "\n", 1);
+    (void)n;
+
+    _exit(127);
+}
+
+static void childSetup(gpointer data) {
+    VteGoChildSetup *setup = data;
+
+    if (setup->reset_signal_mask) {
+        sigset_t set;
+        sigemptyset(&set);
+        if (sigprocmask(SIG_SETMASK, &set, NULL) != 0) {
+            childSetupFail("sigprocmask");
+        }
+
+        // SIGKILL and SIGSTOP cannot be reset, errors are ignored.
+        for (int sig = 1; sig < NSIG; sig++) {
+            signal(sig, SIG_DFL);
+        }
+    }
+
+    // Session leader already leads its own process group.
+    if (setup->new_process_group && getpgrp() != getpid()) {
+        if (setpgid(0, 0) != 0) {
+            childSetupFail("setpgid");
+        }
+    }
+
+    if (setup->set_umask) {
+        umask(setup->umask);
+    }
+
+    for (int i = 0; i < setup->n_rlimits; i++) {
+        struct rlimit rl = {
+            .rlim_cur = setup->rlimits[i].cur,
+            .rlim_max = setup->rlimits[i].max,
+        };
+        if (setrlimit(setup->rlimits[i].resource, &rl) != 0) {
+            childSetupFail("setrlimit");
+        }
+    }
+
+    if (setup->nice != 0) {
+        errno = 0;
+        if (nice(setup->nice) == -1 && errno != 0) {
+            childSetupFail("nice");
+        }
+    }
+}
+
+// Data of every spawn. It is destroyed by VTE once the spawn operation is
+// complete, which releases the Go side of the spawn.
+typedef struct {
+    uintptr_t handle;
+    VteGoChildSetup *setup;
+} VteGoSpawnData;
+
+static VteGoSpawnData *spawnDataNew(uintptr_t handle, VteGoChildSetup *setup) {
+    VteGoSpawnData *data = g_new0(VteGoSpawnData, 1);
+    data->handle = handle;
+    data->setup = setup;
+    return data;
+}
+
+static void spawnChildSetup(gpointer data) {
+    VteGoSpawnData *spawn_data = data;
+
+    if (spawn_data->setup != NULL) {
+        childSetup(spawn_data->setup);
+    }
+}
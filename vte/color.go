@@ -0,0 +1,142 @@
+package vte
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+)
+
+// Color is a value-type counterpart of [gdk.RGBA]. Components are in range
+// [0, 1] and are not premultiplied by alpha.
+//
+// Color implements [color.Color], so it can be converted to and from colors
+// of the standard library.
+type Color struct {
+	R, G, B, A float64
+}
+
+// RGB returns opaque color from 8-bit components.
+func RGB(r, g, b uint8) Color {
+	return Color{
+		R: float64(r) / 0xff,
+		G: float64(g) / 0xff,
+		B: float64(b) / 0xff,
+		A: 1,
+	}
+}
+
+// ColorFromGdk returns color equal to c. If c is nil, transparent black is
+// returned.
+func ColorFromGdk(c *gdk.RGBA) Color {
+	if c == nil {
+		return Color{}
+	}
+	return Color{
+		R: c.GetRed(),
+		G: c.GetGreen(),
+		B: c.GetBlue(),
+		A: c.GetAlpha(),
+	}
+}
+
+// ColorFrom converts any [color.Color] to [Color].
+func ColorFrom(c color.Color) Color {
+	if c, ok := c.(Color); ok {
+		return c
+	}
+
+	n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+	return Color{
+		R: float64(n.R) / 0xffff,
+		G: float64(n.G) / 0xffff,
+		B: float64(n.B) / 0xffff,
+		A: float64(n.A) / 0xffff,
+	}
+}
+
+// RGBA implements [color.Color]. It returns alpha-premultiplied components.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	n := color.NRGBA64{
+		R: colorComponent16(c.R),
+		G: colorComponent16(c.G),
+		B: colorComponent16(c.B),
+		A: colorComponent16(c.A),
+	}
+	return n.RGBA()
+}
+
+// Gdk returns a new [gdk.RGBA] equal to c.
+func (c Color) Gdk() *gdk.RGBA {
+	return gdk.NewRGBA(c.R, c.G, c.B, c.A)
+}
+
+// String returns color in hexadecimal notation, i.e. #rrggbb for opaque
+// colors, and #rrggbbaa otherwise.
+func (c Color) String() string {
+	s := fmt.Sprintf(
+		"#%02x%02x%02x",
+		colorComponent8(c.R),
+		colorComponent8(c.G),
+		colorComponent8(c.B),
+	)
+
+	if a := colorComponent8(c.A); a != 0xff {
+		s += fmt.Sprintf("%02x", a)
+	}
+
+	return s
+}
+
+func colorComponent8(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 0xff))
+}
+
+func colorComponent16(v float64) uint16 {
+	return uint16(math.Round(math.Max(0, math.Min(1, v)) * 0xffff))
+}
+
+// ParseColor parses the color specification. The following formats are
+// supported:
+//
+//   - CSS color names, e.g. "rebeccapurple", and "transparent";
+//   - #rgb, #rgba, #rrggbb, #rrggbbaa, #rrrgggbbb, and #rrrrggggbbbb;
+//   - X11 rgb:r/g/b, where each component consists of 1 to 4 hexadecimal
+//     digits.
+func ParseColor(s string) (Color, error) {
+	s = strings.TrimSpace(s)
+
+	if c, ok := cssColors[strings.ToLower(s)]; ok {
+		return c, nil
+	}
+
+	// Hexadecimal notation with alpha is not supported by theme.ParseColor.
+	if digits, ok := strings.CutPrefix(s, "#"); ok && (len(digits) == 4 || len(digits) == 8) {
+		n := len(digits) / 4
+
+		var rgba [4]float64
+		for i := range rgba {
+			v, err := strconv.ParseUint(digits[i*n:(i+1)*n], 16, 8)
+			if err != nil {
+				return Color{}, fmt.Errorf("vte: invalid color %q", s)
+			}
+			rgba[i] = float64(v) / float64(uint64(1)<<(4*n)-1)
+		}
+
+		return Color{R: rgba[0], G: rgba[1], B: rgba[2], A: rgba[3]}, nil
+	}
+
+	if strings.HasPrefix(s, "#") || strings.HasPrefix(s, "rgb:") {
+		c, err := theme.ParseColor(s)
+		if err != nil {
+			return Color{}, fmt.Errorf("vte: invalid color %q", s)
+		}
+		return ColorFrom(c), nil
+	}
+
+	return Color{}, fmt.Errorf("vte: invalid color %q", s)
+}
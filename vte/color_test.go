@@ -0,0 +1,94 @@
+package vte
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColor(t *testing.T) {
+	cases := map[string]Color{
+		"#ff8000":                RGB(0xff, 0x80, 0x00),
+		"#F80":                   RGB(0xff, 0x88, 0x00),
+		"#ff800080":              {R: 1, G: float64(0x80) / 0xff, B: 0, A: float64(0x80) / 0xff},
+		"#f808":                  {R: 1, G: float64(0x88) / 0xff, B: 0, A: float64(0x88) / 0xff},
+		"rgb:ff/80/00":           RGB(0xff, 0x80, 0x00),
+		"rgb:f/8/0":              RGB(0xff, 0x88, 0x00),
+		"green":                  RGB(0x00, 0x80, 0x00),
+		"RebeccaPurple":          RGB(0x66, 0x33, 0x99),
+		" lightgoldenrodyellow ": RGB(0xfa, 0xfa, 0xd2),
+		"transparent":            {},
+	}
+
+	for s, expected := range cases {
+		c, err := ParseColor(s)
+		assert.NoError(t, err, s)
+		assert.InDelta(t, expected.R, c.R, 1e-9, s)
+		assert.InDelta(t, expected.G, c.G, 1e-9, s)
+		assert.InDelta(t, expected.B, c.B, 1e-9, s)
+		assert.InDelta(t, expected.A, c.A, 1e-9, s)
+	}
+
+	for _, s := range []string{"", "#", "#ff", "#gggggg", "#ff80zz80", "0xff8000", "rgb:1/2", "notacolor"} {
+		_, err := ParseColor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestColor(t *testing.T) {
+	c := RGB(0xff, 0x80, 0x00)
+	assert.Equal(t, "#ff8000", c.String())
+	assert.Equal(t, "#ff800080", Color{R: 1, G: c.G, B: 0, A: float64(0x80) / 0xff}.String())
+
+	assert.Equal(t, color.NRGBA{0xff, 0x80, 0x00, 0xff}, color.NRGBAModel.Convert(c))
+	assert.Equal(t, c, ColorFrom(color.NRGBA{0xff, 0x80, 0x00, 0xff}))
+	assert.Equal(t, c, ColorFrom(c))
+
+	// Components are premultiplied by alpha.
+	r, _, _, a := Color{R: 1, A: 0.5}.RGBA()
+	assert.Equal(t, a, r)
+
+	g := c.Gdk()
+	assert.Equal(t, []float64{c.R, c.G, c.B, c.A}, g.Floats())
+	assert.Equal(t, c, ColorFromGdk(g))
+	assert.Equal(t, Color{}, ColorFromGdk(nil))
+}
+
+func TestXtermPalette(t *testing.T) {
+	var base [16]Color
+	for i := range base {
+		base[i] = RGB(uint8(i), uint8(i), uint8(i))
+	}
+
+	p := XtermPalette(base)
+	assert.Len(t, p, 256)
+	assert.NoError(t, p.Validate())
+
+	assert.Equal(t, base[:], []Color(p[:16]))
+	assert.Equal(t, RGB(0x00, 0x00, 0x00), p[16])
+	assert.Equal(t, RGB(0x5f, 0x87, 0xaf), p[67])
+	assert.Equal(t, RGB(0xff, 0xff, 0xff), p[231])
+	assert.Equal(t, RGB(0x08, 0x08, 0x08), p[232])
+	assert.Equal(t, RGB(0xee, 0xee, 0xee), p[255])
+}
+
+func TestDefaultPalette(t *testing.T) {
+	p := DefaultPalette()
+	assert.Len(t, p, 256)
+
+	assert.Equal(t, Color{0, 0, 0, 1}, p[0])
+	assert.Equal(t, Color{0.75, 0, 0, 1}, p[1])
+	assert.Equal(t, Color{0.75, 0.75, 0.75, 1}, p[7])
+	assert.Equal(t, Color{0.25, 0.25, 0.25, 1}, p[8])
+	assert.Equal(t, Color{1, 0.25, 0.25, 1}, p[9])
+	assert.Equal(t, Color{1, 1, 1, 1}, p[15])
+}
+
+func TestPalette_Validate(t *testing.T) {
+	for _, n := range []int{0, 8, 16, 232, 256} {
+		assert.NoError(t, make(Palette, n).Validate())
+	}
+	assert.Error(t, make(Palette, 17).Validate())
+	assert.Len(t, make(Palette, 16).Gdk(), 16)
+}
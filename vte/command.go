@@ -1,8 +1,13 @@
 package vte
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gotk3/gotk3/glib"
@@ -35,20 +40,65 @@ type Command struct {
 	// GLib Cancellable object.
 	Cancellable *glib.Cancellable
 
+	// ExtraFiles specifies additional open files to be inherited by the
+	// command. File i becomes file descriptor 3+i in the command. Nil entries
+	// leave the corresponding descriptor closed.
+	//
+	// Descriptors are duplicated before spawning, so files remain owned by the
+	// caller and can be closed as soon as Spawn returns.
+	ExtraFiles []*os.File
+
+	// ChildSetup describes setup performed in the child process before the
+	// command is executed. If nil, no additional setup is performed.
+	ChildSetup *ChildSetup
+
 	// OnSpawn is a callback that runs when command is spawned.
 	// The second argument indicates whether there was an error.
 	OnSpawn func(pid int, err error)
+
+	// Context of the command, set by [CommandContext].
+	ctx context.Context
 }
 
-// CommandWithEnv appends variable to the command environment.
+// CommandWithEnv sets variable in the command environment, overriding its
+// previous value. If environment of the command is nil, variable is set on top
+// of the environment of the current process.
 //
 // Can be used multiple times.
 func CommandWithEnv(name string, value any) CommandOption {
 	return func(c *Command) {
-		c.Env = append(c.Env, fmt.Sprintf("%s=%v", name, value))
+		c.Env = append(envUnset(c.Env, name), fmt.Sprintf("%s=%v", name, value))
+	}
+}
+
+// CommandWithoutEnv removes variable from the command environment. If
+// environment of the command is nil, variable is removed from the environment
+// inherited from the current process.
+//
+// Can be used multiple times.
+func CommandWithoutEnv(name string) CommandOption {
+	return func(c *Command) {
+		c.Env = envUnset(c.Env, name)
 	}
 }
 
+// envUnset returns a copy of env without variable name. If env is nil,
+// environment of the current process is used. env itself is not modified, as
+// it may be shared by several commands.
+func envUnset(env []string, name string) []string {
+	if env == nil {
+		env = os.Environ()
+	}
+
+	result := make([]string, 0, len(env)+1)
+	for _, kv := range env {
+		if k, _, _ := strings.Cut(kv, "="); k != name {
+			result = append(result, kv)
+		}
+	}
+	return result
+}
+
 // CommandWithWorkdir sets command working directory.
 func CommandWithWorkdir(workdir string) CommandOption {
 	return func(c *Command) {
@@ -88,6 +138,24 @@ func CommandWithCancellable(cancellable *glib.Cancellable) CommandOption {
 	}
 }
 
+// CommandWithExtraFiles appends files inherited by the command, see
+// [Command.ExtraFiles].
+//
+// Can be used multiple times.
+func CommandWithExtraFiles(files ...*os.File) CommandOption {
+	return func(c *Command) {
+		c.ExtraFiles = append(c.ExtraFiles, files...)
+	}
+}
+
+// CommandWithChildSetup sets setup performed in the child process before the
+// command is executed, see [ChildSetup].
+func CommandWithChildSetup(setup *ChildSetup) CommandOption {
+	return func(c *Command) {
+		c.ChildSetup = setup
+	}
+}
+
 // CommandWithOnSpawn sets callback that runs when command starts or fails to
 // start.
 func CommandWithOnSpawn(callback func(pid int, err error)) CommandOption {
@@ -111,6 +179,173 @@ func CommandNew(args []string, options ...CommandOption) *Command {
 	return c
 }
 
+// CommandContext is like [CommandNew], but binds the command to ctx. When ctx
+// is done, the spawn is cancelled, or the process is killed if it has already
+// been spawned.
+func CommandContext(ctx context.Context, args []string, options ...CommandOption) *Command {
+	if ctx == nil {
+		panic("vte: nil Context")
+	}
+
+	c := CommandNew(args, options...)
+	c.ctx = ctx
+	return c
+}
+
+// Environ returns the environment the command is spawned with. If the same
+// variable is set more than once, only the last value is kept.
+func (c *Command) Environ() []string {
+	env := c.Env
+	if env == nil {
+		env = os.Environ()
+	}
+
+	var (
+		seen   = make(map[string]bool, len(env))
+		result = make([]string, 0, len(env))
+	)
+
+	for _, kv := range slices.Backward(env) {
+		k, _, _ := strings.Cut(kv, "=")
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, kv)
+	}
+
+	slices.Reverse(result)
+	return result
+}
+
+// LookPath returns path of the executable of the command (Args[0]).
+//
+// If Args[0] contains a slash, it is resolved relative to Dir. Otherwise, it
+// is searched in directories named by the PATH variable of the command
+// environment, see [Command.Environ].
+func (c *Command) LookPath() (string, error) {
+	if len(c.Args) == 0 {
+		return "", errors.New("vte: command has no arguments")
+	}
+
+	name := c.Args[0]
+	if strings.Contains(name, "/") {
+		return c.lookPath(name, "")
+	}
+
+	var path string
+	for _, kv := range c.Environ() {
+		if v, ok := strings.CutPrefix(kv, "PATH="); ok {
+			path = v
+		}
+	}
+
+	return c.lookPath(name, path)
+}
+
+// lookPath searches executable name in directories of path. If name contains
+// a slash, path is ignored and name is resolved relative to Dir.
+func (c *Command) lookPath(name, path string) (string, error) {
+	if strings.Contains(name, "/") {
+		if !filepath.IsAbs(name) && c.Dir != "" {
+			name = filepath.Join(c.Dir, name)
+		}
+
+		if err := checkExecutable(name); err != nil {
+			return "", fmt.Errorf("vte: executable %q: %w", name, err)
+		}
+		return name, nil
+	}
+
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			dir = "."
+		}
+
+		file := filepath.Join(dir, name)
+		if !filepath.IsAbs(file) && c.Dir != "" {
+			file = filepath.Join(c.Dir, file)
+		}
+
+		if checkExecutable(file) == nil {
+			return file, nil
+		}
+	}
+
+	return "", fmt.Errorf("vte: executable %q not found in PATH", name)
+}
+
+// checkExecutable returns an error if file is not a regular executable file.
+func checkExecutable(file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return errors.New("is a directory")
+	}
+
+	if info.Mode().Perm()&0o111 == 0 {
+		return os.ErrPermission
+	}
+
+	return nil
+}
+
+// Validate reports whether the command can be spawned. It checks arguments,
+// environment, working directory and the executable.
+//
+// Spawn functions validate the command before spawning, and report errors via
+// OnSpawn.
+func (c *Command) Validate() error {
+	if len(c.Args) == 0 || c.Args[0] == "" {
+		return errors.New("vte: command has no executable")
+	}
+
+	for _, kv := range c.Env {
+		if !strings.Contains(kv, "=") {
+			return fmt.Errorf("vte: malformed environment variable %q", kv)
+		}
+	}
+
+	if c.Dir != "" {
+		info, err := os.Stat(c.Dir)
+		if err != nil {
+			return fmt.Errorf("vte: working directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("vte: working directory %q is not a directory", c.Dir)
+		}
+	}
+
+	name := c.Args[0]
+
+	switch {
+	case strings.Contains(name, "/"):
+		_, err := c.lookPath(name, "")
+		return err
+	case c.SpawnFlags&SPAWN_SEARCH_PATH_FROM_ENVP != 0:
+		_, err := c.LookPath()
+		return err
+	case c.SpawnFlags&SPAWN_SEARCH_PATH != 0:
+		_, err := c.lookPath(name, os.Getenv("PATH"))
+		return err
+	default:
+		// Without search flags, executable is resolved relative to the working
+		// directory.
+		if _, err := c.lookPath("./"+name, ""); err != nil {
+			return fmt.Errorf("%w (use SPAWN_SEARCH_PATH to search PATH)", err)
+		}
+		return nil
+	}
+}
+
+// String returns a human-readable description of the command.
+func (c *Command) String() string {
+	return strings.Join(c.Args, " ")
+}
+
 // commandSetDefaults populates empty fields of [Command] with default values.
 func commandSetDefaults(c *Command) {
 	if c.Dir == "" {
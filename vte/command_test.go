@@ -1,12 +1,18 @@
 package vte_test
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
 	"github.com/shelepuginivan/gotk3-vte/vte"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,20 +34,142 @@ func TestNewCommand(t *testing.T) {
 }
 
 func TestCommandWithEnv(t *testing.T) {
+	t.Setenv("X_VTE_TEST", "inherited")
+
 	cmd := vte.CommandNew(
 		nil,
 		vte.CommandWithEnv("X_VTE_TEST", "TestCommandWithEnv"),
 		vte.CommandWithEnv("X_VTE_ANOTHER", "something"),
+		vte.CommandWithEnv("X_VTE_ANOTHER", "overridden"),
 	)
 
-	expected := []string{
+	expected := append(
+		slices.DeleteFunc(os.Environ(), func(kv string) bool {
+			return strings.HasPrefix(kv, "X_VTE_TEST=")
+		}),
 		"X_VTE_TEST=TestCommandWithEnv",
-		"X_VTE_ANOTHER=something",
-	}
+		"X_VTE_ANOTHER=overridden",
+	)
 
 	assert.Equal(t, expected, cmd.Env)
 }
 
+func TestCommandWithoutEnv(t *testing.T) {
+	t.Setenv("X_VTE_TEST", "inherited")
+
+	cmd := vte.CommandNew(nil, vte.CommandWithoutEnv("X_VTE_TEST"))
+
+	assert.NotEmpty(t, cmd.Env)
+	assert.NotContains(t, cmd.Env, "X_VTE_TEST=inherited")
+}
+
+func TestCommandWithEnv_SharedEnv(t *testing.T) {
+	base := []string{"A=1", "B=2", "C=3"}
+
+	first := vte.CommandNew(nil)
+	first.Env = base
+	vte.CommandWithoutEnv("A")(first)
+
+	second := vte.CommandNew(nil)
+	second.Env = base
+	vte.CommandWithEnv("B", 4)(second)
+
+	assert.Equal(t, []string{"A=1", "B=2", "C=3"}, base)
+	assert.Equal(t, []string{"B=2", "C=3"}, first.Env)
+	assert.Equal(t, []string{"A=1", "C=3", "B=4"}, second.Env)
+}
+
+func TestCommand_Environ(t *testing.T) {
+	cmd := &vte.Command{
+		Env: []string{"A=1", "B=2", "A=3"},
+	}
+	assert.Equal(t, []string{"B=2", "A=3"}, cmd.Environ())
+
+	cmd.Env = nil
+	assert.Equal(t, os.Environ(), cmd.Environ())
+}
+
+func TestCommand_LookPath(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "x-vte-test"), []byte("#!/bin/sh\n"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "x-vte-noexec"), nil, 0o644))
+
+	cmd := vte.CommandNew([]string{"x-vte-test"}, vte.CommandWithEnv("PATH", "/nonexistent:"+dir))
+	path, err := cmd.LookPath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "x-vte-test"), path)
+
+	cmd = vte.CommandNew([]string{"./x-vte-test"}, vte.CommandWithWorkdir(dir))
+	path, err = cmd.LookPath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "x-vte-test"), path)
+
+	cmd = vte.CommandNew([]string{"x-vte-noexec"}, vte.CommandWithEnv("PATH", dir))
+	_, err = cmd.LookPath()
+	assert.Error(t, err)
+
+	cmd = vte.CommandNew([]string{"x-vte-test"}, vte.CommandWithEnv("PATH", "/nonexistent"))
+	_, err = cmd.LookPath()
+	assert.Error(t, err)
+}
+
+func TestCommand_Validate(t *testing.T) {
+	assert.NoError(t, vte.CommandNew([]string{"/bin/sh"}).Validate())
+	assert.NoError(t, vte.CommandNew(
+		[]string{"sh"},
+		vte.CommandWithSpawnFlags(vte.SPAWN_SEARCH_PATH),
+	).Validate())
+
+	for name, cmd := range map[string]*vte.Command{
+		"No arguments":      vte.CommandNew(nil),
+		"Empty executable":  vte.CommandNew([]string{""}),
+		"Missing file":      vte.CommandNew([]string{"/nonexistent"}),
+		"Not in PATH":       vte.CommandNew([]string{"x-vte-nonexistent"}, vte.CommandWithSpawnFlags(vte.SPAWN_SEARCH_PATH)),
+		"Bare name":         vte.CommandNew([]string{"sh"}),
+		"Directory":         vte.CommandNew([]string{"/tmp"}),
+		"Missing workdir":   vte.CommandNew([]string{"/bin/sh"}, vte.CommandWithWorkdir("/nonexistent")),
+		"Malformed env":     {Args: []string{"/bin/sh"}, Env: []string{"X_VTE_TEST"}},
+		"Workdir not a dir": vte.CommandNew([]string{"/bin/sh"}, vte.CommandWithWorkdir("/bin/sh")),
+	} {
+		assert.Error(t, cmd.Validate(), name)
+	}
+}
+
+func TestCommand_String(t *testing.T) {
+	cmd := vte.CommandNew([]string{"/bin/sh", "-c", "echo something"})
+	assert.Equal(t, "/bin/sh -c echo something", cmd.String())
+}
+
+func TestCommandContext(t *testing.T) {
+	gtk.Init(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cmd := vte.CommandContext(
+		ctx,
+		[]string{"/bin/sleep", "10"},
+		vte.CommandWithOnSpawn(func(pid int, err error) {
+			assert.NoError(t, err)
+			cancel()
+		}),
+	)
+
+	p := newPty(t).Spawn(cmd)
+	waitProcess(p)
+
+	status, err := p.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, syscall.SIGKILL, status.Signal())
+
+	t.Run("Done before spawn", func(t *testing.T) {
+		p := newPty(t).Spawn(vte.CommandContext(ctx, []string{"/bin/sleep", "10"}))
+		waitProcess(p)
+
+		_, err := p.Wait()
+		assert.Error(t, err)
+	})
+}
+
 func TestCommandWithWorkdir(t *testing.T) {
 	cmd := vte.CommandNew(
 		nil,
@@ -0,0 +1,131 @@
+package vte
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// Directory represents working directory of the shell running in [Terminal].
+type Directory struct {
+	// Host the directory is located on, or an empty string ("") if the
+	// directory is located on the local machine.
+	Host string
+
+	// Absolute path of the directory.
+	Path string
+}
+
+// IsLocal reports whether the directory is located on the local machine.
+func (d *Directory) IsLocal() bool {
+	return d.Host == ""
+}
+
+// String returns path of the directory. Path of the remote directory is
+// prefixed with the host, e.g. "host:/home/user".
+func (d *Directory) String() string {
+	if d.IsLocal() {
+		return d.Path
+	}
+	return d.Host + ":" + d.Path
+}
+
+// CurrentDirectory returns working directory of the shell running in the
+// terminal.
+//
+// The directory is parsed from [TERMPROP_CURRENT_DIRECTORY_URI], which is set
+// by the shell with OSC 7. If the shell does not set it, working directory of
+// the foreground process group of the terminal is read from /proc.
+func (t *Terminal) CurrentDirectory() (*Directory, error) {
+	uri, err := t.GetTermPropURI(TERMPROP_CURRENT_DIRECTORY_URI)
+	if err == nil {
+		return parseDirectoryURI(uri)
+	}
+	if !errors.Is(err, ErrTermPropUnset) {
+		return nil, err
+	}
+
+	info, err := t.ForegroundProcess()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Cwd == "" {
+		return nil, fmt.Errorf("vte: working directory of process %d is unavailable", info.Pid)
+	}
+
+	return &Directory{Path: info.Cwd}, nil
+}
+
+// ConnectCurrentDirectoryChanged calls callback when the shell running in the
+// terminal reports a new working directory with OSC 7. Malformed directory
+// URIs are ignored.
+func (t *Terminal) ConnectCurrentDirectoryChanged(callback func(t *Terminal, dir *Directory)) glib.SignalHandle {
+	return t.ConnectTermPropChanged(func(t *Terminal, prop TermProp) {
+		if prop != TERMPROP_CURRENT_DIRECTORY_URI {
+			return
+		}
+
+		uri, err := t.GetTermPropURI(prop)
+		if err != nil {
+			return
+		}
+
+		dir, err := parseDirectoryURI(uri)
+		if err != nil {
+			return
+		}
+
+		callback(t, dir)
+	})
+}
+
+// parseDirectoryURI parses file URI set by OSC 7 into [Directory].
+func parseDirectoryURI(uri *url.URL) (*Directory, error) {
+	if uri.Scheme != "file" {
+		return nil, fmt.Errorf("vte: unsupported directory URI scheme %q", uri.Scheme)
+	}
+
+	if !filepath.IsAbs(uri.Path) {
+		return nil, fmt.Errorf("vte: directory URI path %q is not absolute", uri.Path)
+	}
+
+	dir := &Directory{
+		Path: filepath.Clean(uri.Path),
+	}
+
+	if !isLocalHost(uri.Hostname()) {
+		dir.Host = uri.Hostname()
+	}
+
+	return dir, nil
+}
+
+// isLocalHost reports whether host refers to the local machine.
+func isLocalHost(host string) bool {
+	if host == "" || strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return false
+	}
+
+	if strings.EqualFold(host, hostname) {
+		return true
+	}
+
+	// Shells may report either short or fully qualified hostname.
+	if strings.Contains(host, ".") && strings.Contains(hostname, ".") {
+		return false
+	}
+	host, _, _ = strings.Cut(host, ".")
+	hostname, _, _ = strings.Cut(hostname, ".")
+	return strings.EqualFold(host, hostname)
+}
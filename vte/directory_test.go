@@ -0,0 +1,50 @@
+package vte
+
+import (
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseDirectoryURI(t *testing.T) {
+	hostname, err := os.Hostname()
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		uri      string
+		expected *Directory
+	}{
+		{"file:///home/user", &Directory{Path: "/home/user"}},
+		{"file://localhost/tmp/", &Directory{Path: "/tmp"}},
+		{"file://" + hostname + "/home/user/a%20b", &Directory{Path: "/home/user/a b"}},
+		{"file://remote.example.com/srv", &Directory{Host: "remote.example.com", Path: "/srv"}},
+	} {
+		uri, err := url.Parse(tc.uri)
+		assert.NoError(t, err)
+
+		dir, err := parseDirectoryURI(uri)
+		assert.NoError(t, err, tc.uri)
+		assert.Equal(t, tc.expected, dir, tc.uri)
+	}
+
+	for _, s := range []string{"https://example.com/home", "file:relative"} {
+		uri, err := url.Parse(s)
+		assert.NoError(t, err)
+
+		dir, err := parseDirectoryURI(uri)
+		assert.Nil(t, dir, s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestDirectory_String(t *testing.T) {
+	local := &Directory{Path: "/home/user"}
+	assert.True(t, local.IsLocal())
+	assert.Equal(t, "/home/user", local.String())
+
+	remote := &Directory{Host: "remote", Path: "/srv"}
+	assert.False(t, remote.IsLocal())
+	assert.Equal(t, "remote:/srv", remote.String())
+}
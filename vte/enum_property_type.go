@@ -0,0 +1,42 @@
+package vte
+
+// #include <vte/vte.h>
+import "C"
+
+// PropertyType is an enumeration type that represents type of the termprop.
+type PropertyType int
+
+const (
+	// Termprop has no value. It can only be set or reset.
+	PROPERTY_VALUELESS PropertyType = C.VTE_PROPERTY_VALUELESS
+
+	// Boolean termprop.
+	PROPERTY_BOOL PropertyType = C.VTE_PROPERTY_BOOL
+
+	// Signed 64-bit integer termprop.
+	PROPERTY_INT PropertyType = C.VTE_PROPERTY_INT
+
+	// Unsigned 64-bit integer termprop.
+	PROPERTY_UINT PropertyType = C.VTE_PROPERTY_UINT
+
+	// Floating-point number termprop.
+	PROPERTY_DOUBLE PropertyType = C.VTE_PROPERTY_DOUBLE
+
+	// Color termprop without alpha component.
+	PROPERTY_RGB PropertyType = C.VTE_PROPERTY_RGB
+
+	// Color termprop with alpha component.
+	PROPERTY_RGBA PropertyType = C.VTE_PROPERTY_RGBA
+
+	// UTF-8 string termprop.
+	PROPERTY_STRING PropertyType = C.VTE_PROPERTY_STRING
+
+	// Binary data termprop.
+	PROPERTY_DATA PropertyType = C.VTE_PROPERTY_DATA
+
+	// UUID termprop.
+	PROPERTY_UUID PropertyType = C.VTE_PROPERTY_UUID
+
+	// URI termprop.
+	PROPERTY_URI PropertyType = C.VTE_PROPERTY_URI
+)
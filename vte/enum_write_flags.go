@@ -0,0 +1,13 @@
+package vte
+
+// #include <vte/vte.h>
+import "C"
+
+// WriteFlags is an enumeration type that can be used to specify how the
+// terminal contents should be written by [Terminal.WriteContents].
+type WriteFlags int
+
+const (
+	// Write contents as UTF-8 text. This is the default.
+	WRITE_DEFAULT WriteFlags = C.VTE_WRITE_DEFAULT
+)
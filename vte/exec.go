@@ -3,84 +3,202 @@ package vte
 // #include <glib.h>
 // #include <gtk/gtk.h>
 // #include <vte/vte.h>
+// #include "child_setup.go.h"
+// #include "exec.go.h"
 // #include "glib.go.h"
 import "C"
 import (
-	"sync"
+	"context"
+	"os"
+	"runtime/cgo"
+	"sync/atomic"
+	"syscall"
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
 )
 
-var (
-	vteAsyncExecLock sync.Mutex
-	vteAsyncExecMap  = make(map[uint]*Command)
-)
+// spawnEntry is the Go side of the asynchronous spawn. It is passed to C code
+// as [cgo.Handle] and released by both the spawn callback and the destroy
+// notify of the spawn data, whichever comes last.
+type spawnEntry struct {
+	cmd  *Command
+	refs atomic.Int32
+}
 
-func assignCallID(cmd *Command) uint {
-	callID := uint(1)
+// onSpawnRelease is called when the spawn entry is released. It is only set
+// in tests.
+var onSpawnRelease func()
 
-	vteAsyncExecLock.Lock()
-	defer vteAsyncExecLock.Unlock()
+// newSpawnHandle returns handle of the new spawn entry for cmd.
+func newSpawnHandle(cmd *Command) cgo.Handle {
+	entry := &spawnEntry{cmd: cmd}
+	entry.refs.Store(2)
+	return cgo.NewHandle(entry)
+}
 
-	for callID != 0 {
-		_, exists := vteAsyncExecMap[callID]
-		if !exists {
-			vteAsyncExecMap[callID] = cmd
-			return callID
+// releaseSpawnHandle releases one reference of the spawn entry. The handle is
+// deleted when both the callback and the destroy notify are done with it.
+func releaseSpawnHandle(handle cgo.Handle) {
+	entry := handle.Value().(*spawnEntry)
+	if entry.refs.Add(-1) == 0 {
+		handle.Delete()
+		if onSpawnRelease != nil {
+			onSpawnRelease()
 		}
-		callID++
 	}
-	return 0
 }
 
-//export ptySpawnAsyncCallback
-func ptySpawnAsyncCallback(source *C.VtePty, res *C.GAsyncResult, cCallID C.gpointer) {
-	callID := uint(C.gpointerToUint(cCallID))
-	if callID == 0 {
-		return
+// cSpawnData returns child setup function, its data and the function that
+// frees the data. The data carries handle of the spawn entry and optional
+// setup of the child process.
+func cSpawnData(handle cgo.Handle, setup *ChildSetup) (C.GSpawnChildSetupFunc, C.gpointer, C.GDestroyNotify) {
+	data := C.spawnDataNew(C.uintptr_t(handle), cChildSetup(setup))
+
+	return C.GSpawnChildSetupFunc(C.spawnChildSetup),
+		C.gpointer(unsafe.Pointer(data)),
+		C.GDestroyNotify(C.spawnDataDestroy)
+}
+
+//export spawnDataDestroy
+func spawnDataDestroy(p C.gpointer) {
+	data := (*C.VteGoSpawnData)(unsafe.Pointer(p))
+
+	releaseSpawnHandle(cgo.Handle(data.handle))
+
+	C.g_free(C.gpointer(unsafe.Pointer(data.setup)))
+	C.g_free(p)
+}
+
+// spawnContext spawns copy of cmd with spawn and iterates the default GLib
+// main context until the spawn completes. When ctx is done, cancellable of the
+// command is cancelled and ctx.Err() is returned.
+func spawnContext(ctx context.Context, cmd *Command, spawn func(*Command) *Process) (int, error) {
+	if err := ctx.Err(); err != nil {
+		if cmd.OnSpawn != nil {
+			cmd.OnSpawn(-1, err)
+		}
+		return -1, err
 	}
 
-	vteAsyncExecLock.Lock()
+	var (
+		c    = *cmd
+		done bool
+		pid  int
+		err  error
+	)
+
+	commandSetDefaults(&c)
 
-	cmd, exists := vteAsyncExecMap[callID]
-	if !exists {
-		vteAsyncExecLock.Unlock()
-		return
+	c.OnSpawn = func(p int, e error) {
+		done, pid, err = true, p, e
+		if cmd.OnSpawn != nil {
+			cmd.OnSpawn(p, e)
+		}
 	}
 
-	delete(vteAsyncExecMap, callID)
-	vteAsyncExecLock.Unlock()
+	stop := context.AfterFunc(ctx, c.Cancellable.Cancel)
+	defer stop()
 
-	if cmd.OnSpawn != nil {
-		pty := wrapPty(glib.Take(unsafe.Pointer(source)))
-		cmd.OnSpawn(pty.spawnFinish(res))
+	spawn(&c)
+
+	mainContext := glib.MainContextDefault()
+	for !done {
+		mainContext.Iteration(true)
 	}
+
+	if err != nil && ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
+
+	return pid, err
 }
 
-//export terminalSpawnAsyncCallback
-func terminalSpawnAsyncCallback(_ *C.VteTerminal, pid C.GPid, gerr *C.GError, cCallID C.gpointer) {
-	callID := uint(C.gpointerToUint(cCallID))
-	if callID == 0 {
-		return
+// handleDestroy deletes [cgo.Handle] passed to C code as user data. It is used
+// as GDestroyNotify.
+//
+//export handleDestroy
+func handleDestroy(data C.gpointer) {
+	cgo.Handle(C.gpointerToUintptr(data)).Delete()
+}
+
+// spawnPrepare validates cmd and duplicates descriptors of its extra files,
+// see [cExtraFiles].
+func spawnPrepare(cmd *Command) (fds, mapFds []C.int, err error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, nil, err
 	}
+	return cExtraFiles(cmd.ExtraFiles)
+}
+
+// cExtraFiles duplicates descriptors of files with FD_CLOEXEC set, since VTE
+// takes ownership of the descriptors passed to the child. Descriptor of
+// files[i] is mapped to 3+i in the child.
+//
+// Returned descriptors are closed by VTE, regardless of whether spawn succeeds.
+func cExtraFiles(files []*os.File) (fds, mapFds []C.int, err error) {
+	for i, f := range files {
+		if f == nil {
+			continue
+		}
 
-	vteAsyncExecLock.Lock()
+		fd, _, errno := syscall.Syscall(
+			syscall.SYS_FCNTL,
+			f.Fd(),
+			syscall.F_DUPFD_CLOEXEC,
+			3,
+		)
+		if errno != 0 {
+			for _, fd := range fds {
+				syscall.Close(int(fd))
+			}
+			return nil, nil, os.NewSyscallError("fcntl", errno)
+		}
 
-	cmd, exists := vteAsyncExecMap[callID]
-	if !exists {
-		vteAsyncExecLock.Unlock()
-		return
+		fds = append(fds, C.int(fd))
+		mapFds = append(mapFds, C.int(3+i))
 	}
 
-	delete(vteAsyncExecMap, callID)
-	vteAsyncExecLock.Unlock()
+	return fds, mapFds, nil
+}
+
+// cIntArrPtr returns pointer to the first element of arr, or nil if arr is
+// empty.
+func cIntArrPtr(arr []C.int) *C.int {
+	if len(arr) == 0 {
+		return nil
+	}
+	return &arr[0]
+}
+
+//export ptySpawnAsyncCallback
+func ptySpawnAsyncCallback(source *C.VtePty, res *C.GAsyncResult, data C.gpointer) {
+	handle := cgo.Handle(C.gpointerToUintptr(data))
+	defer releaseSpawnHandle(handle)
+
+	cmd := handle.Value().(*spawnEntry).cmd
+
+	pty := wrapPty(glib.Take(unsafe.Pointer(source)))
+	pid, err := pty.spawnFinish(res)
+
+	if cmd.OnSpawn != nil {
+		cmd.OnSpawn(pid, err)
+	}
+}
+
+//export terminalSpawnAsyncCallback
+func terminalSpawnAsyncCallback(_ *C.VteTerminal, pid C.GPid, gerr *C.GError, data C.gpointer) {
+	handle := cgo.Handle(C.gpointerToUintptr(data))
+	defer releaseSpawnHandle(handle)
+
+	cmd := handle.Value().(*spawnEntry).cmd
 
 	var err error
 	if gerr != nil {
-		err = errFromGError("vte_terminal_spawn_async", gerr)
-		C.g_error_free(gerr)
+		err = errFromGError("vte_terminal_spawn_with_fds_async", gerr)
 	}
 
-	cmd.OnSpawn(int(pid), err)
+	if cmd.OnSpawn != nil {
+		cmd.OnSpawn(int(pid), err)
+	}
 }
@@ -1,4 +1,7 @@
 #include <vte/vte.h>
 
-extern void ptySpawnAsyncCallback(VtePty *o, GAsyncResult *res, gpointer call_id);
-extern void terminalSpawnAsyncCallback(VtePty* o, GPid pid, GError *err, gpointer data);
+extern void ptySpawnAsyncCallback(VtePty *o, GAsyncResult *res, gpointer data);
+extern void terminalSpawnAsyncCallback(VteTerminal *o, GPid pid, GError *err, gpointer data);
+extern void processChildWatchCallback(GPid pid, gint status, gpointer data);
+extern void handleDestroy(gpointer data);
+extern void spawnDataDestroy(gpointer data);
@@ -0,0 +1,60 @@
+package vte
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpawnHandlesDoNotLeak(t *testing.T) {
+	gtk.Init(nil)
+
+	const n = 2000
+
+	cancellable, err := glib.CancellableNew()
+	assert.NoError(t, err)
+
+	pty, err := PtyNewSync(PTY_DEFAULT, cancellable)
+	assert.NoError(t, err)
+
+	before := spawnReleases.Load()
+
+	var called, expected int
+
+	for i := range n {
+		cmd := CommandNew([]string{"/bin/true"})
+
+		switch {
+		case i%3 == 0:
+			// Cancelled before it starts, without callback.
+			cmd.Cancellable.Cancel()
+			pty.spawn(cmd)
+		case i%100 == 1:
+			term, err := TerminalNew()
+			assert.NoError(t, err)
+
+			expected++
+			cmd.OnSpawn = func(int, error) { called++ }
+			term.Spawn(cmd)
+		default:
+			expected++
+			cmd.OnSpawn = func(int, error) { called++ }
+			pty.Spawn(cmd)
+		}
+	}
+
+	mainContext := glib.MainContextDefault()
+	deadline := time.Now().Add(time.Minute)
+
+	// Every spawn, including the cancelled ones, releases its entry. Entries
+	// of spawns started by other tests may be released meanwhile as well.
+	for spawnReleases.Load()-before < n && time.Now().Before(deadline) {
+		mainContext.Iteration(true)
+	}
+
+	assert.GreaterOrEqual(t, spawnReleases.Load()-before, int64(n))
+	assert.Equal(t, expected, called)
+}
@@ -0,0 +1,59 @@
+package vte
+
+import (
+	"strconv"
+	"syscall"
+)
+
+// ExitStatus represents wait status of the child process, as reported by
+// [Terminal.ConnectChildExitedStatus] and [Process.Wait].
+type ExitStatus int
+
+// Exited reports whether the process has exited normally.
+func (s ExitStatus) Exited() bool {
+	return syscall.WaitStatus(s).Exited()
+}
+
+// ExitCode returns exit code of the process, or -1 if the process has not
+// exited normally.
+func (s ExitStatus) ExitCode() int {
+	if !s.Exited() {
+		return -1
+	}
+	return syscall.WaitStatus(s).ExitStatus()
+}
+
+// Signaled reports whether the process was terminated by a signal.
+func (s ExitStatus) Signaled() bool {
+	return syscall.WaitStatus(s).Signaled()
+}
+
+// Signal returns signal that terminated the process, or -1 if the process was
+// not terminated by a signal.
+func (s ExitStatus) Signal() syscall.Signal {
+	if !s.Signaled() {
+		return -1
+	}
+	return syscall.WaitStatus(s).Signal()
+}
+
+// CoreDumped reports whether the process dumped core when it was terminated
+// by a signal.
+func (s ExitStatus) CoreDumped() bool {
+	return syscall.WaitStatus(s).CoreDump()
+}
+
+// String returns human-readable representation of the status, e.g.
+// "exit status 1" or "signal: killed".
+func (s ExitStatus) String() string {
+	switch {
+	case s.Exited():
+		return "exit status " + strconv.Itoa(s.ExitCode())
+	case s.Signaled() && s.CoreDumped():
+		return "signal: " + s.Signal().String() + " (core dumped)"
+	case s.Signaled():
+		return "signal: " + s.Signal().String()
+	default:
+		return "wait status " + strconv.Itoa(int(s))
+	}
+}
@@ -0,0 +1,32 @@
+package vte_test
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitStatus(t *testing.T) {
+	exited := vte.ExitStatus(3 << 8)
+	assert.True(t, exited.Exited())
+	assert.Equal(t, 3, exited.ExitCode())
+	assert.False(t, exited.Signaled())
+	assert.Equal(t, syscall.Signal(-1), exited.Signal())
+	assert.False(t, exited.CoreDumped())
+	assert.Equal(t, "exit status 3", exited.String())
+
+	killed := vte.ExitStatus(syscall.SIGKILL)
+	assert.False(t, killed.Exited())
+	assert.Equal(t, -1, killed.ExitCode())
+	assert.True(t, killed.Signaled())
+	assert.Equal(t, syscall.SIGKILL, killed.Signal())
+	assert.False(t, killed.CoreDumped())
+	assert.Equal(t, "signal: killed", killed.String())
+
+	dumped := vte.ExitStatus(int(syscall.SIGSEGV) | 0x80)
+	assert.True(t, dumped.Signaled())
+	assert.True(t, dumped.CoreDumped())
+	assert.Equal(t, "signal: segmentation fault (core dumped)", dumped.String())
+}
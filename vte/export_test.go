@@ -0,0 +1,26 @@
+package vte
+
+import "sync/atomic"
+
+// spawnReleases is the number of spawn entries released so far.
+var spawnReleases atomic.Int64
+
+func init() {
+	onSpawnRelease = func() {
+		spawnReleases.Add(1)
+	}
+}
+
+// TrackedColors returns cursor, highlight and bold colors tracked for the
+// terminal. Nil means that the default is used.
+func (t *Terminal) TrackedColors() (cursor, cursorText, highlight, highlightText, bold *Color) {
+	s := t.paletteState()
+	return s.cursor, s.cursorText, s.highlight, s.highlightText, s.bold
+}
+
+// TrackedDefaultColors returns the default foreground and background colors
+// tracked for the terminal.
+func (t *Terminal) TrackedDefaultColors() (foreground, background Color) {
+	s := t.paletteState()
+	return s.foreground, s.background
+}
@@ -0,0 +1,201 @@
+package vte
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ErrNoForegroundProcess is returned when the terminal has no foreground
+// process group, e.g. if no process is spawned in it.
+var ErrNoForegroundProcess = errors.New("vte: no foreground process group")
+
+// ProcessInfo describes a process running in [Terminal].
+type ProcessInfo struct {
+	// ID of the process.
+	Pid int
+
+	// ID of the process group of the process.
+	Pgid int
+
+	// Name of the executable of the process, possibly truncated by the kernel.
+	Comm string
+
+	// Command line arguments of the process.
+	Cmdline []string
+
+	// Working directory of the process, or an empty string ("") if it cannot
+	// be read, e.g. due to insufficient permissions.
+	Cwd string
+}
+
+// ForegroundProcess returns information about the leader of the foreground
+// process group of the terminal. If only the shell is running, this is the
+// shell itself, so comparing Pid with PID of the spawned shell tells whether
+// another command is running.
+//
+// Information is read from /proc, so this is only supported on Linux.
+func (t *Terminal) ForegroundProcess() (*ProcessInfo, error) {
+	pgid, err := t.foregroundPgid()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := readProcessInfo(pgid)
+	if err == nil {
+		return info, nil
+	}
+
+	// Leader of the process group has exited, but other members are running.
+	pid, err := findProcessGroupMember(pgid)
+	if err != nil {
+		return nil, err
+	}
+
+	return readProcessInfo(pid)
+}
+
+// SendSignalToForeground sends sig to every process in the foreground process
+// group of the terminal, e.g. [syscall.SIGINT] to interrupt the running
+// command.
+//
+// Signal is not sent if the foreground process group is the process group of
+// the calling process, which is the case if the terminal shares it with the
+// application.
+func (t *Terminal) SendSignalToForeground(sig syscall.Signal) error {
+	pgid, err := t.foregroundPgid()
+	if err != nil {
+		return err
+	}
+
+	if pgid == syscall.Getpgrp() {
+		return errors.New("vte: refusing to signal own process group")
+	}
+
+	return syscall.Kill(-pgid, sig)
+}
+
+// foregroundPgid returns ID of the foreground process group of the terminal.
+// If there is no foreground process group, [ErrNoForegroundProcess] is
+// returned.
+func (t *Terminal) foregroundPgid() (int, error) {
+	pty := t.GetPty()
+	if pty == nil {
+		return 0, errors.New("vte: terminal has no pty")
+	}
+
+	pgid, err := pty.foregroundPgid()
+	if err != nil {
+		return 0, err
+	}
+
+	// Kill treats non-positive PIDs specially, e.g. -0 would signal the
+	// process group of the caller.
+	if pgid <= 0 {
+		return 0, ErrNoForegroundProcess
+	}
+
+	return pgid, nil
+}
+
+// readProcessInfo reads information about the process from /proc.
+func readProcessInfo(pid int) (*ProcessInfo, error) {
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+
+	stat, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return nil, err
+	}
+
+	_, pgid, err := parseProcStat(stat)
+	if err != nil {
+		return nil, err
+	}
+
+	comm, err := os.ReadFile(filepath.Join(dir, "comm"))
+	if err != nil {
+		return nil, err
+	}
+
+	cmdline, err := os.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Working directory of processes of other users is not readable.
+	cwd, _ := os.Readlink(filepath.Join(dir, "cwd"))
+
+	return &ProcessInfo{
+		Pid:     pid,
+		Pgid:    pgid,
+		Comm:    strings.TrimSuffix(string(comm), "\n"),
+		Cmdline: parseProcCmdline(cmdline),
+		Cwd:     cwd,
+	}, nil
+}
+
+// findProcessGroupMember returns ID of any process in the process group pgid.
+func findProcessGroupMember(pgid int) (int, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		stat, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+
+		if _, g, err := parseProcStat(stat); err == nil && g == pgid {
+			return pid, nil
+		}
+	}
+
+	return 0, errors.New("vte: no process in foreground process group")
+}
+
+// parseProcStat returns parent process ID and process group ID from contents
+// of /proc/<pid>/stat, see man:proc_pid_stat(5).
+func parseProcStat(stat []byte) (ppid, pgid int, err error) {
+	// Executable name is enclosed in parentheses and may contain spaces and
+	// parentheses itself.
+	end := bytes.LastIndexByte(stat, ')')
+	if end == -1 {
+		return 0, 0, errors.New("vte: malformed process stat")
+	}
+
+	// Fields after the name: state ppid pgrp ...
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 3 {
+		return 0, 0, errors.New("vte: malformed process stat")
+	}
+
+	if ppid, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, err
+	}
+
+	if pgid, err = strconv.Atoi(fields[2]); err != nil {
+		return 0, 0, err
+	}
+
+	return ppid, pgid, nil
+}
+
+// parseProcCmdline splits contents of /proc/<pid>/cmdline into arguments.
+func parseProcCmdline(cmdline []byte) []string {
+	cmdline = bytes.TrimSuffix(cmdline, []byte{0})
+	if len(cmdline) == 0 {
+		return nil
+	}
+	return strings.Split(string(cmdline), "\x00")
+}
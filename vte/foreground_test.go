@@ -0,0 +1,41 @@
+package vte
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseProcStat(t *testing.T) {
+	ppid, pgid, err := parseProcStat([]byte("1234 (a (b) c) S 1200 1234 1200 34816 1234 4194304"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1200, ppid)
+	assert.Equal(t, 1234, pgid)
+
+	_, _, err = parseProcStat([]byte("1234 (sh"))
+	assert.Error(t, err)
+
+	_, _, err = parseProcStat([]byte("1234 (sh) S"))
+	assert.Error(t, err)
+}
+
+func Test_parseProcCmdline(t *testing.T) {
+	assert.Equal(t, []string{"sh", "-c", "echo a b", ""}, parseProcCmdline([]byte("sh\x00-c\x00echo a b\x00\x00")))
+	assert.Nil(t, parseProcCmdline(nil))
+}
+
+func Test_readProcessInfo(t *testing.T) {
+	info, err := readProcessInfo(os.Getpid())
+	assert.NoError(t, err)
+
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+
+	assert.Equal(t, os.Getpid(), info.Pid)
+	assert.Equal(t, syscall.Getpgrp(), info.Pgid)
+	assert.Equal(t, os.Args, info.Cmdline)
+	assert.Equal(t, cwd, info.Cwd)
+	assert.NotEmpty(t, info.Comm)
+}
@@ -1,6 +1,7 @@
 #include <gio/gio.h>
 #include <glib.h>
 #include <glib-object.h>
+#include <stdint.h>
 
 static GCancellable *toCancellable(void *p) {
     return (G_CANCELLABLE(p));
@@ -33,3 +34,11 @@ static gsize uintToGsize(uint i) {
 static gssize intToGssize(int i) {
     return ((gssize)i);
 }
+
+static uintptr_t gpointerToUintptr(gpointer p) {
+    return ((uintptr_t)p);
+}
+
+static gpointer uintptrToGpointer(uintptr_t i) {
+    return ((gpointer)i);
+}
@@ -0,0 +1,219 @@
+package vte
+
+import (
+	"fmt"
+
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+)
+
+// Palette is a terminal color palette. It may contain 0, 8, 16, 232, or 256
+// colors, see [Terminal.SetColors].
+type Palette []Color
+
+// Validate checks that the palette has a supported number of colors. The
+// rule is the same as for [theme.Theme.Validate].
+func (p Palette) Validate() error {
+	if err := theme.ValidatePaletteSize(len(p)); err != nil {
+		return fmt.Errorf("vte: %w", err)
+	}
+	return nil
+}
+
+// Gdk converts the palette to the slice of [gdk.RGBA], as accepted by
+// [Terminal.SetColors].
+func (p Palette) Gdk() []*gdk.RGBA {
+	colors := make([]*gdk.RGBA, len(p))
+	for i, c := range p {
+		colors[i] = c.Gdk()
+	}
+	return colors
+}
+
+// XtermPalette builds the 256-color xterm palette from 16 base colors. Colors
+// 16-231 form a 6x6x6 color cube, colors 232-255 form a grayscale ramp.
+func XtermPalette(base [16]Color) Palette {
+	p := make(Palette, 256)
+	copy(p, base[:])
+
+	levels := [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+
+	for i := range 216 {
+		p[16+i] = RGB(levels[i/36], levels[i/6%6], levels[i%6])
+	}
+
+	for i := range 24 {
+		v := uint8(8 + 10*i)
+		p[232+i] = RGB(v, v, v)
+	}
+
+	return p
+}
+
+// DefaultPalette returns the 256-color palette that the terminal uses by
+// default, see [Terminal.SetDefaultColors].
+func DefaultPalette() Palette {
+	var base [16]Color
+
+	for i := range base {
+		var r, g, b float64
+
+		if i&1 != 0 {
+			r = 0.75
+		}
+		if i&2 != 0 {
+			g = 0.75
+		}
+		if i&4 != 0 {
+			b = 0.75
+		}
+
+		// Bright colors.
+		if i > 7 {
+			r, g, b = r+0.25, g+0.25, b+0.25
+		}
+
+		base[i] = Color{R: r, G: g, B: b, A: 1}
+	}
+
+	return XtermPalette(base)
+}
+
+// Default foreground and background colors of the terminal.
+var (
+	defaultForeground = Color{R: 0.75, G: 0.75, B: 0.75, A: 1}
+	defaultBackground = Color{R: 0, G: 0, B: 0, A: 1}
+)
+
+// paletteState tracks colors of the terminal, since VTE provides no getters
+// for them.
+type paletteState struct {
+	foreground Color
+	background Color
+	palette    Palette
+
+	// Colors that are reset by vte_terminal_set_colors. Nil means that the
+	// default is used.
+	cursor        *Color
+	cursorText    *Color
+	highlight     *Color
+	highlightText *Color
+	bold          *Color
+}
+
+// paletteState returns tracked colors of the terminal.
+func (t *Terminal) paletteState() *paletteState {
+	if s, ok := t.getData(terminalDataPalette).(*paletteState); ok {
+		return s
+	}
+
+	s := &paletteState{
+		foreground: defaultForeground,
+		background: defaultBackground,
+		palette:    DefaultPalette(),
+	}
+	t.setData(terminalDataPalette, s)
+	return s
+}
+
+// trackColors updates tracked colors after [Terminal.SetColors]. It follows
+// the rules of vte_terminal_set_colors for nil colors and omitted entries.
+func (t *Terminal) trackColors(background, foreground *gdk.RGBA, palette []*gdk.RGBA) {
+	s := t.paletteState()
+
+	s.cursor, s.cursorText = nil, nil
+	s.highlight, s.highlightText = nil, nil
+	s.bold = nil
+
+	s.palette = DefaultPalette()
+	for i, c := range palette {
+		s.palette[i] = ColorFromGdk(c)
+	}
+
+	switch {
+	case foreground != nil:
+		s.foreground = ColorFromGdk(foreground)
+	case len(palette) > 0:
+		s.foreground = s.palette[7]
+	default:
+		s.foreground = defaultForeground
+	}
+
+	switch {
+	case background != nil:
+		s.background = ColorFromGdk(background)
+	case len(palette) > 0:
+		s.background = s.palette[0]
+	default:
+		s.background = defaultBackground
+	}
+}
+
+// SetPalette sets the foreground color, the background color and the palette
+// of the terminal. It is a value-type counterpart of [Terminal.SetColors].
+//
+// Like [Terminal.SetColors], it resets cursor, highlight and bold colors to
+// defaults. Use [Terminal.SetPaletteColor] to change a single color.
+func (t *Terminal) SetPalette(foreground, background Color, palette Palette) error {
+	if err := palette.Validate(); err != nil {
+		return err
+	}
+	return t.SetColors(background.Gdk(), foreground.Gdk(), palette.Gdk())
+}
+
+// GetPalette returns the full 256-color palette of the terminal.
+//
+// VTE does not allow to query the palette, so it is tracked by the bindings.
+// Colors set with [Terminal.SetColors] and related methods are reported, but
+// changes made by the application running in the terminal, e.g. with OSC 4,
+// are not.
+func (t *Terminal) GetPalette() Palette {
+	s := t.paletteState()
+
+	p := make(Palette, len(s.palette))
+	copy(p, s.palette)
+	return p
+}
+
+// SetPaletteColor sets i-th color of the palette to c, keeping other colors
+// of the terminal intact, including cursor, highlight and bold colors.
+func (t *Terminal) SetPaletteColor(i int, c Color) error {
+	s := t.paletteState()
+
+	if i < 0 || i >= len(s.palette) {
+		return fmt.Errorf("vte: palette index %d is out of range 0-%d", i, len(s.palette)-1)
+	}
+
+	p := make(Palette, len(s.palette))
+	copy(p, s.palette)
+	p[i] = c
+
+	// SetPalette resets these colors, so they are re-applied.
+	prev := *s
+
+	if err := t.SetPalette(prev.foreground, prev.background, p); err != nil {
+		return err
+	}
+
+	t.SetCursorColor(colorGdkOrNil(prev.cursor), colorGdkOrNil(prev.cursorText))
+	t.SetHighlightColor(colorGdkOrNil(prev.highlight), colorGdkOrNil(prev.highlightText))
+	t.SetColorBold(colorGdkOrNil(prev.bold))
+	return nil
+}
+
+// colorFromGdkOrNil converts c to [Color], or returns nil if c is nil.
+func colorFromGdkOrNil(c *gdk.RGBA) *Color {
+	if c == nil {
+		return nil
+	}
+	v := ColorFromGdk(c)
+	return &v
+}
+
+// colorGdkOrNil converts c to [gdk.RGBA], or returns nil if c is nil.
+func colorGdkOrNil(c *Color) *gdk.RGBA {
+	if c == nil {
+		return nil
+	}
+	return c.Gdk()
+}
@@ -0,0 +1,116 @@
+package vte_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/shelepuginivan/gotk3-vte/vte"
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminal_GetPalette(t *testing.T) {
+	term := newTerm(t)
+
+	assert.Equal(t, vte.DefaultPalette(), term.GetPalette())
+
+	assert.NoError(t, term.SetColors(nil, nil, []*gdk.RGBA{
+		gdk.NewRGBA(0, 0, 0, 1),
+		gdk.NewRGBA(1, 0, 0, 1),
+		gdk.NewRGBA(0, 1, 0, 1),
+		gdk.NewRGBA(1, 1, 0, 1),
+		gdk.NewRGBA(0, 0, 1, 1),
+		gdk.NewRGBA(1, 0, 1, 1),
+		gdk.NewRGBA(0, 1, 1, 1),
+		gdk.NewRGBA(1, 1, 1, 1),
+	}))
+
+	palette := term.GetPalette()
+	assert.Len(t, palette, 256)
+	assert.Equal(t, vte.Color{R: 1, A: 1}, palette[1])
+
+	// Omitted entries are defaults.
+	assert.Equal(t, vte.DefaultPalette()[8:], palette[8:])
+
+	// Returned palette is a copy.
+	palette[1] = vte.Color{}
+	assert.Equal(t, vte.Color{R: 1, A: 1}, term.GetPalette()[1])
+
+	term.SetDefaultColors()
+	assert.Equal(t, vte.DefaultPalette(), term.GetPalette())
+}
+
+func TestTerminal_SetPaletteColor(t *testing.T) {
+	term := newTerm(t)
+
+	red, err := vte.ParseColor("#ff8000")
+	assert.NoError(t, err)
+
+	assert.NoError(t, term.SetPaletteColor(1, red))
+	assert.Equal(t, red, term.GetPalette()[1])
+	assert.Equal(t, vte.DefaultPalette()[2], term.GetPalette()[2])
+
+	feed(term, "\x1b[31mred\x1b[0m")
+
+	snapshot, err := term.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, red.String(), vte.ColorFromGdk(snapshot.Rows[0][0].Foreground).String())
+
+	assert.Error(t, term.SetPaletteColor(-1, red))
+	assert.Error(t, term.SetPaletteColor(256, red))
+}
+
+func TestTerminal_SetPalette(t *testing.T) {
+	term := newTerm(t)
+
+	var base [16]vte.Color
+	for i := range base {
+		base[i] = vte.RGB(uint8(i*16), 0, 0)
+	}
+
+	palette := vte.XtermPalette(base)
+	assert.NoError(t, term.SetPalette(vte.RGB(0xff, 0xff, 0xff), vte.RGB(0, 0, 0), palette))
+	assert.Equal(t, palette, term.GetPalette())
+
+	assert.Error(t, term.SetPalette(vte.Color{}, vte.Color{}, make(vte.Palette, 3)))
+}
+
+func TestTerminal_SetPaletteColorKeepsColors(t *testing.T) {
+	term := newTerm(t)
+
+	var (
+		cursor    = vte.RGB(0xff, 0x00, 0x00)
+		highlight = vte.RGB(0x00, 0xff, 0x00)
+		bold      = vte.RGB(0x00, 0x00, 0xff)
+	)
+
+	term.SetCursorColor(cursor.Gdk(), nil)
+	term.SetHighlightColor(nil, highlight.Gdk())
+	term.SetColorBold(bold.Gdk())
+
+	assert.NoError(t, term.SetPaletteColor(1, vte.RGB(0xff, 0x80, 0x00)))
+
+	c, ct, h, ht, b := term.TrackedColors()
+	assert.Equal(t, &cursor, c)
+	assert.Nil(t, ct)
+	assert.Nil(t, h)
+	assert.Equal(t, &highlight, ht)
+	assert.Equal(t, &bold, b)
+
+	// SetPalette resets them, as vte_terminal_set_colors does.
+	assert.NoError(t, term.SetPalette(vte.RGB(0xc0, 0xc0, 0xc0), vte.RGB(0, 0, 0), nil))
+
+	c, _, _, ht, b = term.TrackedColors()
+	assert.Nil(t, c)
+	assert.Nil(t, ht)
+	assert.Nil(t, b)
+}
+
+func TestPalette_Validate(t *testing.T) {
+	// Palette and theme.Theme accept the same number of colors.
+	for n := range 258 {
+		th := theme.Theme{Palette: make([]color.NRGBA, n)}
+		assert.Equal(t, th.Validate() == nil, make(vte.Palette, n).Validate() == nil, n)
+	}
+}
@@ -0,0 +1,248 @@
+package vte
+
+// #include <glib.h>
+// #include "exec.go.h"
+// #include "glib.go.h"
+import "C"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/cgo"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// ErrProcessNotStarted is returned by methods of [Process] if the process has
+// not been spawned yet.
+var ErrProcessNotStarted = errors.New("vte: process not started")
+
+// Process represents a child process spawned with [Terminal.Spawn] or
+// [Pty.Spawn].
+//
+// Process is spawned asynchronously, so its PID is not known until the
+// command's OnSpawn callback is called. Process state is updated from the
+// GLib main loop, hence [Process.Wait] must not be called from the thread
+// running it.
+type Process struct {
+	mu sync.Mutex
+
+	ctx    context.Context
+	pid    int
+	since  uint64
+	err    error
+	status ExitStatus
+	exited bool
+
+	// Set when the terminal watching the process is destroyed before the
+	// process is spawned.
+	detached bool
+
+	done chan struct{}
+}
+
+// pendingProcesses holds processes spawned with [Pty.Spawn] during their
+// OnSpawn callback, so that [Terminal.WatchChild] can claim them.
+var pendingProcesses sync.Map
+
+func newProcess() *Process {
+	return &Process{
+		done: make(chan struct{}),
+	}
+}
+
+// Pid returns PID of the process, or 0 if the process has not been spawned
+// yet or failed to spawn.
+func (p *Process) Pid() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pid
+}
+
+// Done returns a channel that is closed when the process exits or fails to
+// spawn.
+func (p *Process) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait blocks until the process exits and returns its exit status. If the
+// process failed to spawn, the spawn error is returned.
+func (p *Process) Wait() (ExitStatus, error) {
+	<-p.done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status, p.err
+}
+
+// ExitStatus returns exit status of the process. The second return value
+// reports whether the process has exited.
+func (p *Process) ExitStatus() (ExitStatus, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status, p.exited
+}
+
+// Signal sends sig to the process. If the process has already exited,
+// [os.ErrProcessDone] is returned.
+//
+// The process is reaped by GLib before its exit status is delivered to the
+// main loop, so its PID may be reused in between. To avoid signalling an
+// unrelated process, start time of the process is compared with the one
+// recorded at spawn. This narrows, but does not close, the race window.
+func (p *Process) Signal(sig os.Signal) error {
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		return errors.New("vte: unsupported signal type")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case p.exited:
+		return os.ErrProcessDone
+	case p.err != nil:
+		return p.err
+	case p.pid == 0:
+		return ErrProcessNotStarted
+	}
+
+	if since, err := processStartTime(p.pid); err != nil || since != p.since {
+		return os.ErrProcessDone
+	}
+
+	return syscall.Kill(p.pid, s)
+}
+
+// Kill causes the process to exit immediately.
+func (p *Process) Kill() error {
+	return p.Signal(os.Kill)
+}
+
+// start records result of the spawn.
+func (p *Process) start(pid int, err error) {
+	p.mu.Lock()
+
+	if err != nil {
+		p.err = err
+		close(p.done)
+		p.mu.Unlock()
+		return
+	}
+
+	p.pid = pid
+
+	// If the process has already been reaped, start time is left zero and
+	// the process is reported as done by Signal.
+	p.since, _ = processStartTime(pid)
+
+	// Context was done while the process was being spawned.
+	if p.ctx != nil && p.ctx.Err() != nil {
+		syscall.Kill(pid, syscall.SIGKILL)
+	}
+
+	detached := p.detached
+	p.mu.Unlock()
+
+	if detached {
+		p.watch()
+	}
+}
+
+// detach is called when the terminal watching the process is destroyed. The
+// terminal no longer reports exit of the process, so it is watched with GLib
+// child watch instead, and [Process.Done] is still closed eventually.
+func (p *Process) detach() {
+	p.mu.Lock()
+
+	switch {
+	case p.exited, p.err != nil:
+		p.mu.Unlock()
+	case p.pid == 0:
+		p.detached = true
+		p.mu.Unlock()
+	default:
+		p.mu.Unlock()
+		p.watch()
+	}
+}
+
+// processStartTime returns start time of the process in clock ticks after
+// system boot, as reported by /proc/<pid>/stat, see man:proc_pid_stat(5).
+func processStartTime(pid int) (uint64, error) {
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, err
+	}
+
+	// Executable name is enclosed in parentheses and may contain spaces and
+	// parentheses itself.
+	end := bytes.LastIndexByte(stat, ')')
+	if end == -1 {
+		return 0, errors.New("vte: malformed process stat")
+	}
+
+	// Start time is the 22nd field, i.e. the 20th field after the name.
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 20 {
+		return 0, errors.New("vte: malformed process stat")
+	}
+
+	return strconv.ParseUint(fields[19], 10, 64)
+}
+
+// watchContext cancels spawn of the process, or kills the process, when ctx
+// is done. It must be called before the process is spawned.
+func (p *Process) watchContext(ctx context.Context, cancellable *glib.Cancellable) {
+	p.ctx = ctx
+
+	stop := context.AfterFunc(ctx, func() {
+		cancellable.Cancel()
+		p.Kill()
+	})
+
+	go func() {
+		<-p.done
+		stop()
+	}()
+}
+
+// exit records exit status of the process.
+func (p *Process) exit(status ExitStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.exited {
+		return
+	}
+
+	p.status = status
+	p.exited = true
+	close(p.done)
+}
+
+// watch adds GLib child watch for the process spawned with [Pty.Spawn], or for
+// the process whose terminal has been destroyed.
+func (p *Process) watch() {
+	C.g_child_watch_add_full(
+		C.G_PRIORITY_DEFAULT,
+		C.GPid(p.Pid()),
+		C.GChildWatchFunc(C.processChildWatchCallback),
+		C.uintptrToGpointer(C.uintptr_t(cgo.NewHandle(p))),
+		C.GDestroyNotify(C.handleDestroy),
+	)
+}
+
+//export processChildWatchCallback
+func processChildWatchCallback(pid C.GPid, status C.gint, data C.gpointer) {
+	p := cgo.Handle(C.gpointerToUintptr(data)).Value().(*Process)
+	C.g_spawn_close_pid(pid)
+	p.exit(ExitStatus(status))
+}
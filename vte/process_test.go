@@ -0,0 +1,21 @@
+package vte
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessStartTime(t *testing.T) {
+	since, err := processStartTime(os.Getpid())
+	assert.NoError(t, err)
+	assert.NotZero(t, since)
+
+	again, err := processStartTime(os.Getpid())
+	assert.NoError(t, err)
+	assert.Equal(t, since, again)
+
+	_, err = processStartTime(-1)
+	assert.Error(t, err)
+}
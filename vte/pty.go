@@ -8,8 +8,10 @@ package vte
 // #include "vte.go.h"
 import "C"
 import (
+	"context"
 	"fmt"
 	"os"
+	"syscall"
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
@@ -97,6 +99,82 @@ func (pty *Pty) GetFd() uintptr {
 	return uintptr(C.vte_pty_get_fd(pty.native()))
 }
 
+// Master returns a new file for the PTY master in pty. The returned file
+// descriptor is a duplicate of [Pty.GetFd], so it can be used with the Go
+// runtime poller (deadlines, concurrent Read and Close) and must be closed by
+// the caller. Note that file status flags, such as O_NONBLOCK, are shared with
+// the descriptor owned by pty.
+func (pty *Pty) Master() (*os.File, error) {
+	r, _, errno := syscall.Syscall(
+		syscall.SYS_FCNTL,
+		pty.GetFd(),
+		syscall.F_DUPFD_CLOEXEC,
+		0,
+	)
+	if errno != 0 {
+		return nil, os.NewSyscallError("fcntl", errno)
+	}
+
+	fd := int(r)
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("fcntl", err)
+	}
+
+	return os.NewFile(uintptr(fd), "/dev/ptmx"), nil
+}
+
+// OpenSlave opens the PTY slave corresponding to the PTY master in pty. The
+// slave is opened without becoming the controlling terminal of the calling
+// process.
+//
+// The slave can be used to attach an in-process program to the
+// pseudo-terminal instead of spawning a child process.
+func (pty *Pty) OpenSlave() (*os.File, error) {
+	var n uint32
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		pty.GetFd(),
+		syscall.TIOCGPTN,
+		uintptr(unsafe.Pointer(&n)),
+	)
+	if errno != 0 {
+		return nil, os.NewSyscallError("ioctl", errno)
+	}
+
+	name := fmt.Sprintf("/dev/pts/%d", n)
+	return os.OpenFile(name, os.O_RDWR|syscall.O_NOCTTY, 0)
+}
+
+// ChildSetup sets up the calling process to run in pty: it starts a new
+// session, makes the PTY slave its controlling terminal and connects its
+// standard input, output and error to the slave.
+//
+// It is intended to be called in a child process, e.g. from a child setup
+// function. Calling it in the main program replaces its standard streams.
+func (pty *Pty) ChildSetup() {
+	C.vte_pty_child_setup(pty.native())
+}
+
+// foregroundPgid returns ID of the foreground process group of the pseudo
+// terminal, like tcgetpgrp(3).
+func (pty *Pty) foregroundPgid() (int, error) {
+	var pgid int32
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		pty.GetFd(),
+		syscall.TIOCGPGRP,
+		uintptr(unsafe.Pointer(&pgid)),
+	)
+	if errno != 0 {
+		return 0, os.NewSyscallError("tcgetpgrp", errno)
+	}
+
+	return int(pgid), nil
+}
+
 // GetSize returns size of the pseudo terminal.
 func (pty *Pty) GetSize() (*PtySize, error) {
 	var (
@@ -181,36 +259,91 @@ func (pty *Pty) SetUTF8(v bool) error {
 // and stderr of the child process will always be connected to the PTY. Also
 // [SPAWN_LEAVE_DESCRIPTORS_OPEN] is not supported; and
 // [SPAWN_DO_NOT_REAP_CHILD] will always be added to spawn_flags.
-func (pty *Pty) Spawn(cmd *Command) {
-	var ccallID C.gpointer
-	if cmd.OnSpawn != nil {
-		callID := assignCallID(cmd)
-		ccallID = C.uintToGpointer(C.uint(callID))
+//
+// Files from cmd.ExtraFiles are inherited by the child process, see
+// [Command.ExtraFiles]. All other descriptors are closed in the child.
+//
+// cmd is checked with [Command.Validate] before spawning, and errors are
+// reported via cmd.OnSpawn. In particular, a program name without a slash in
+// cmd.Args[0] is resolved relative to cmd.Dir, unless [SPAWN_SEARCH_PATH] or
+// [SPAWN_SEARCH_PATH_FROM_ENVP] is set in cmd.SpawnFlags. Hence e.g. "bash"
+// is rejected without these flags, even though it is in PATH.
+//
+// The returned [Process] is notified when the child process exits. If the
+// child process is watched with [Terminal.WatchChild], it must be called in
+// cmd.OnSpawn, so that the process is not watched twice.
+func (pty *Pty) Spawn(cmd *Command) *Process {
+	p := newProcess()
+
+	c := *cmd
+	c.OnSpawn = func(pid int, err error) {
+		p.start(pid, err)
+
+		if err == nil {
+			pendingProcesses.Store(pid, p)
+		}
+
+		if cmd.OnSpawn != nil {
+			cmd.OnSpawn(pid, err)
+		}
+
+		if err != nil {
+			return
+		}
+
+		// The process is not claimed by Terminal.WatchChild.
+		if _, pending := pendingProcesses.LoadAndDelete(pid); pending {
+			p.watch()
+		}
+	}
+
+	if c.ctx != nil {
+		commandSetDefaults(&c)
+		p.watchContext(c.ctx, c.Cancellable)
 	}
 
+	pty.spawn(&c)
+
+	return p
+}
+
+func (pty *Pty) spawn(cmd *Command) {
+	fds, mapFds, err := spawnPrepare(cmd)
+	if err != nil {
+		if cmd.OnSpawn != nil {
+			cmd.OnSpawn(-1, err)
+		}
+		return
+	}
+
+	handle := newSpawnHandle(cmd)
+
 	var (
-		workdir               = C.CString(cmd.Dir)
-		argv                  = cStringArr(cmd.Args)
-		envv                  = cStringArr(cmd.Env)
-		spawnFlags            = C.GSpawnFlags(cmd.SpawnFlags)
-		childSetup            C.GSpawnChildSetupFunc
-		childSetupData        C.gpointer
-		cTimeout              = C.int(cmd.Timeout.Milliseconds())
-		cCancellable          = C.toCancellable(unsafe.Pointer(cmd.Cancellable.GObject))
-		childSetupDataDestroy C.GDestroyNotify
-		callback              = C.GAsyncReadyCallback(C.ptySpawnAsyncCallback)
-		userData              = ccallID
+		workdir      = C.CString(cmd.Dir)
+		argv         = cStringArr(cmd.Args)
+		envv         = cStringArr(cmd.Environ())
+		spawnFlags   = C.GSpawnFlags(cmd.SpawnFlags)
+		cTimeout     = C.int(cmd.Timeout.Milliseconds())
+		cCancellable = C.toCancellable(unsafe.Pointer(cmd.Cancellable.GObject))
+		callback     = C.GAsyncReadyCallback(C.ptySpawnAsyncCallback)
+		userData     = C.uintptrToGpointer(C.uintptr_t(handle))
 	)
 
 	defer C.free(unsafe.Pointer(workdir))
 	defer cStringArrFree(argv)
 	defer cStringArrFree(envv)
 
-	C.vte_pty_spawn_async(
+	childSetup, childSetupData, childSetupDataDestroy := cSpawnData(handle, cmd.ChildSetup)
+
+	C.vte_pty_spawn_with_fds_async(
 		pty.native(),
 		workdir,
 		&argv[0],
 		&envv[0],
+		cIntArrPtr(fds),
+		C.int(len(fds)),
+		cIntArrPtr(mapFds),
+		C.int(len(mapFds)),
 		spawnFlags,
 		childSetup,
 		childSetupData,
@@ -222,6 +355,16 @@ func (pty *Pty) Spawn(cmd *Command) {
 	)
 }
 
+// SpawnContext is like [Pty.Spawn], but blocks until the command is spawned,
+// iterating the default GLib main context meanwhile. It must be called from
+// the thread that owns the main context, usually the main thread.
+//
+// When ctx is done before the command is spawned, cmd.Cancellable is cancelled
+// and ctx.Err() is returned. cmd.OnSpawn is still called, if it is not nil.
+func (pty *Pty) SpawnContext(ctx context.Context, cmd *Command) (int, error) {
+	return spawnContext(ctx, cmd, pty.Spawn)
+}
+
 func (pty *Pty) spawnFinish(res *C.GAsyncResult) (int, error) {
 	var (
 		gerr *C.GError
@@ -1,9 +1,13 @@
 package vte_test
 
 import (
+	"context"
+	"io"
 	"os"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/gotk3/gotk3/glib"
@@ -118,7 +122,8 @@ func TestPty_Spawn(t *testing.T) {
 		[]string{"/usr/bin/true"},
 		vte.CommandWithOnSpawn(func(pid int, err error) {
 			assert.NoError(t, err)
-			assert.Greater(t, pid, os.Getpid())
+			assert.Greater(t, pid, 0)
+			assert.NoError(t, syscall.Kill(pid, 0))
 			gtk.MainQuit()
 		}),
 	)
@@ -129,3 +134,199 @@ func TestPty_Spawn(t *testing.T) {
 	// test will timeout after 10 minutes.
 	gtk.Main()
 }
+
+func TestPty_SpawnExtraFiles(t *testing.T) {
+	gtk.Init(nil)
+
+	pr, pw, err := os.Pipe()
+	assert.NoError(t, err)
+	defer pr.Close()
+
+	cmd := vte.CommandNew(
+		[]string{"/bin/sh", "-c", "echo passed >&4"},
+		vte.CommandWithExtraFiles(nil, pw),
+		vte.CommandWithOnSpawn(func(pid int, err error) {
+			assert.NoError(t, err)
+			gtk.MainQuit()
+		}),
+	)
+
+	newPty(t).Spawn(cmd)
+
+	// Descriptors are duplicated, so the file can be closed right away.
+	assert.NoError(t, pw.Close())
+
+	gtk.Main()
+
+	out, err := io.ReadAll(pr)
+	assert.NoError(t, err)
+	assert.Equal(t, "passed\n", string(out))
+}
+
+func TestPty_SpawnDuplicateEnv(t *testing.T) {
+	gtk.Init(nil)
+
+	pr, pw, err := os.Pipe()
+	assert.NoError(t, err)
+	defer pr.Close()
+
+	cmd := vte.CommandNew(
+		[]string{"/bin/sh", "-c", "echo \"$VTE_TEST\" >&4"},
+		vte.CommandWithExtraFiles(nil, pw),
+		vte.CommandWithOnSpawn(func(pid int, err error) {
+			assert.NoError(t, err)
+			gtk.MainQuit()
+		}),
+	)
+
+	// The last value takes precedence, as with os/exec.
+	cmd.Env = []string{"VTE_TEST=first", "VTE_TEST=last"}
+
+	newPty(t).Spawn(cmd)
+	assert.NoError(t, pw.Close())
+
+	gtk.Main()
+
+	out, err := io.ReadAll(pr)
+	assert.NoError(t, err)
+	assert.Equal(t, "last\n", string(out))
+}
+
+func TestPty_SpawnContext(t *testing.T) {
+	gtk.Init(nil)
+
+	var called bool
+
+	cmd := vte.CommandNew(
+		[]string{"/usr/bin/true"},
+		vte.CommandWithOnSpawn(func(pid int, err error) {
+			called = true
+		}),
+	)
+
+	pid, err := newPty(t).SpawnContext(context.Background(), cmd)
+	assert.NoError(t, err)
+	assert.Greater(t, pid, 0)
+	assert.NoError(t, syscall.Kill(pid, 0))
+	assert.True(t, called)
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var spawnErr error
+
+		cmd := vte.CommandNew(
+			[]string{"/usr/bin/true"},
+			vte.CommandWithOnSpawn(func(pid int, err error) {
+				assert.Equal(t, -1, pid)
+				spawnErr = err
+			}),
+		)
+
+		pid, err := newPty(t).SpawnContext(ctx, cmd)
+		assert.Equal(t, -1, pid)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, spawnErr, context.Canceled)
+	})
+}
+
+// waitProcess iterates the main context until p is done.
+func waitProcess(p *vte.Process) {
+	for {
+		select {
+		case <-p.Done():
+			return
+		default:
+			glib.MainContextDefault().Iteration(true)
+		}
+	}
+}
+
+func TestPty_SpawnProcess(t *testing.T) {
+	gtk.Init(nil)
+
+	p := newPty(t).Spawn(vte.CommandNew([]string{"/bin/sh", "-c", "exit 3"}))
+	waitProcess(p)
+
+	status, err := p.Wait()
+	assert.NoError(t, err)
+	assert.True(t, status.Exited())
+	assert.Equal(t, 3, status.ExitCode())
+	assert.Greater(t, p.Pid(), 0)
+	assert.ErrorIs(t, p.Kill(), os.ErrProcessDone)
+
+	t.Run("Kill", func(t *testing.T) {
+		var p *vte.Process
+
+		p = newPty(t).Spawn(vte.CommandNew(
+			[]string{"/bin/sleep", "10"},
+			vte.CommandWithOnSpawn(func(pid int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, pid, p.Pid())
+				assert.NoError(t, p.Kill())
+			}),
+		))
+		waitProcess(p)
+
+		status, ok := p.ExitStatus()
+		assert.True(t, ok)
+		assert.True(t, status.Signaled())
+		assert.Equal(t, syscall.SIGKILL, status.Signal())
+	})
+
+	t.Run("Spawn error", func(t *testing.T) {
+		p := newPty(t).Spawn(vte.CommandNew([]string{"/nonexistent"}))
+		waitProcess(p)
+
+		_, err := p.Wait()
+		assert.Error(t, err)
+		assert.Equal(t, 0, p.Pid())
+	})
+}
+
+func TestPty_MasterSlave(t *testing.T) {
+	pty := newPty(t)
+
+	master, err := pty.Master()
+	assert.NoError(t, err)
+	defer master.Close()
+
+	assert.NotEqual(t, pty.GetFd(), master.Fd())
+
+	// Master must be registered with the runtime poller.
+	assert.NoError(t, master.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	slave, err := pty.OpenSlave()
+	assert.NoError(t, err)
+	defer slave.Close()
+
+	_, err = slave.WriteString("hello")
+	assert.NoError(t, err)
+
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(master, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+
+	_, err = master.WriteString("ping\n")
+	assert.NoError(t, err)
+
+	buf = make([]byte, 5)
+	_, err = io.ReadFull(slave, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "ping\n", string(buf))
+
+	t.Run("Deadline", func(t *testing.T) {
+		pty := newPty(t)
+
+		master, err := pty.Master()
+		assert.NoError(t, err)
+		defer master.Close()
+
+		master.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+
+		_, err = master.Read(make([]byte, 1))
+		assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	})
+}
@@ -20,11 +20,21 @@ type RegexOption func(*Regex)
 type Regex struct {
 	ptr *C.VteRegex
 
+	pattern    string
 	purpose    RegexPurpose
 	flags      RegexCompileFlags
 	extraFlags RegexCompileExtraFlags
 }
 
+// RegexMatch represents text matched by [Regex].
+type RegexMatch struct {
+	// Regex that matched the text.
+	Regex *Regex
+
+	// Matched text.
+	Text string
+}
+
 // RegexWithPurpose sets regex purpose.
 func RegexWithPurpose(purpose RegexPurpose) RegexOption {
 	return func(r *Regex) {
@@ -54,6 +64,7 @@ func RegexWithCompileExtraFlags(extraFlags RegexCompileExtraFlags) RegexOption {
 // RegexNew returns a new [Regex].
 func RegexNew(pattern string, options ...RegexOption) (*Regex, error) {
 	r := &Regex{
+		pattern: pattern,
 		purpose: REGEX_PURPOSE_SEARCH,
 
 		// NOTE: both vte_terminal_match_add_regex and vte_terminal_search_add_regex
@@ -76,6 +87,8 @@ func RegexNew(pattern string, options ...RegexOption) (*Regex, error) {
 		function string
 	)
 
+	defer C.free(unsafe.Pointer(cPattern))
+
 	switch r.purpose {
 	case REGEX_PURPOSE_MATCH:
 		r.ptr = C.vte_regex_new_for_match_full(cPattern, cLength, cFlags, cExtraFlags, &cOffset, &gerr)
@@ -99,8 +112,11 @@ func RegexNew(pattern string, options ...RegexOption) (*Regex, error) {
 	return r, nil
 }
 
-func wrapRegex(ptr *C.VteRegex) *Regex {
-	r := &Regex{ptr: ptr}
+// wrapRegex wraps ptr not owned by the caller into [Regex], increasing its
+// reference count.
+func wrapRegex(ptr *C.VteRegex, purpose RegexPurpose) *Regex {
+	C.vte_regex_ref(ptr)
+	r := &Regex{ptr: ptr, purpose: purpose}
 	runtime.SetFinalizer(r, func(r *Regex) { glib.FinalizerStrategy(r.Unref) })
 	return r
 }
@@ -122,6 +138,75 @@ func (r *Regex) Unref() {
 	C.vte_regex_unref(r.ptr)
 }
 
+// Pattern returns the pattern regex was compiled from, or an empty string ("")
+// if regex was not created with [RegexNew].
+func (r *Regex) Pattern() string {
+	return r.pattern
+}
+
+// Purpose returns purpose of regex.
+func (r *Regex) Purpose() RegexPurpose {
+	return r.purpose
+}
+
+// CompileFlags returns flags regex was compiled with.
+func (r *Regex) CompileFlags() RegexCompileFlags {
+	return r.flags
+}
+
+// CompileExtraFlags returns extra flags regex was compiled with.
+func (r *Regex) CompileExtraFlags() RegexCompileExtraFlags {
+	return r.extraFlags
+}
+
+// JIT compiles regex to native machine code with PCRE2 JIT compiler. This
+// significantly speeds up matching, e.g. search in large scrollback.
+//
+// JIT can be called multiple times with different flags to compile code for
+// different matching modes.
+func (r *Regex) JIT(flags RegexJITFlags) error {
+	var gerr *C.GError
+
+	if !goBool(C.vte_regex_jit(r.ptr, C.guint32(flags), &gerr)) {
+		if gerr == nil {
+			return errFailed("vte_regex_jit")
+		}
+
+		defer C.g_error_free(gerr)
+		return errFromGError("vte_regex_jit", gerr)
+	}
+
+	return nil
+}
+
+// Substitute returns subject in which matches of regex are replaced with
+// replacement. Replacement may refer to capture groups, e.g. "$1" or "${name}".
+//
+// See man:pcre2api(3) for more information about substitution.
+func (r *Regex) Substitute(subject, replacement string, flags RegexSubstituteFlags) (string, error) {
+	var (
+		gerr         *C.GError
+		cSubject     = C.CString(subject)
+		cReplacement = C.CString(replacement)
+	)
+
+	defer C.free(unsafe.Pointer(cSubject))
+	defer C.free(unsafe.Pointer(cReplacement))
+
+	cstr := C.vte_regex_substitute(r.ptr, cSubject, cReplacement, C.guint32(flags), &gerr)
+	if gerr != nil {
+		defer C.g_error_free(gerr)
+		return "", errFromGError("vte_regex_substitute", gerr)
+	}
+
+	if cstr == nil {
+		return "", errNilPointer("vte_regex_substitute")
+	}
+	defer C.g_free(C.gpointer(unsafe.Pointer(cstr)))
+
+	return goString(cstr), nil
+}
+
 // Native returns a pointer to the underlying VteRegex.
 func (r *Regex) Native() uintptr {
 	return uintptr(unsafe.Pointer(r.ptr))
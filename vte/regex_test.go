@@ -87,3 +87,45 @@ func TestRegex_Native(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEqual(t, uintptr(unsafe.Pointer(nil)), reg.Native())
 }
+
+func TestRegex_Introspection(t *testing.T) {
+	reg, err := RegexNew(
+		"[a-z]+",
+		RegexWithPurpose(REGEX_PURPOSE_MATCH),
+		RegexWithCompileFlags(REGEX_COMPILE_FLAGS_CASELESS),
+		RegexWithCompileExtraFlags(REGEX_COMPILE_EXTRA_FLAGS_MATCH_WORD),
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "[a-z]+", reg.Pattern())
+	assert.Equal(t, REGEX_PURPOSE_MATCH, reg.Purpose())
+	assert.Equal(t, REGEX_COMPILE_FLAGS_MULTILINE|REGEX_COMPILE_FLAGS_CASELESS, reg.CompileFlags())
+	assert.Equal(t, REGEX_COMPILE_EXTRA_FLAGS_MATCH_WORD, reg.CompileExtraFlags())
+}
+
+func TestRegex_JIT(t *testing.T) {
+	reg, err := RegexNew("[a-z]+")
+	assert.NoError(t, err)
+
+	assert.NoError(t, reg.JIT(REGEX_JIT_FLAGS_COMPLETE))
+	assert.NoError(t, reg.JIT(REGEX_JIT_FLAGS_PARTIAL_SOFT))
+}
+
+func TestRegex_Substitute(t *testing.T) {
+	reg, err := RegexNew(`(\w+)@(\w+)`)
+	assert.NoError(t, err)
+
+	s, err := reg.Substitute("alice@home bob@work", "$2:$1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "home:alice bob@work", s)
+
+	s, err = reg.Substitute("alice@home bob@work", "$2:$1", REGEX_SUBSTITUTE_FLAGS_GLOBAL)
+	assert.NoError(t, err)
+	assert.Equal(t, "home:alice work:bob", s)
+
+	t.Run("Invalid replacement", func(t *testing.T) {
+		s, err := reg.Substitute("alice@home", "$9", 0)
+		assert.Equal(t, "", s)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,154 @@
+package vte
+
+// #include <gtk/gtk.h>
+// #include <vte/vte.h>
+// #include "gtk.go.h"
+// #include "selection.go.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// selectionState holds bounds of the selection made from code, since VTE
+// provides no getter for them.
+type selectionState struct {
+	startRow, startCol int
+	endRow, endCol     int
+
+	// Selected text right after the selection was made. If it differs from
+	// the currently selected text, the selection has been changed since.
+	text string
+}
+
+// SelectRange selects text between the cell at startRow and startCol and the
+// cell at endRow and endCol, inclusive. Rows are counted from the beginning of
+// the scrollback buffer, as in [Terminal.GetTextRange], so that the output of
+// a command reported by [Terminal.ConnectCommandFinished] can be selected.
+//
+// VTE provides no public API to select a range of cells, so the selection is
+// made by a synthesized drag with the primary pointer button, and the
+// terminal must be realized. Meanwhile the terminal is scrolled to the start
+// and to the end of the range, and the scroll position is restored
+// afterwards. As with selection made with the mouse, the terminal grabs
+// focus, and the selected text is copied to the primary selection.
+//
+// If the application running in the terminal has enabled mouse tracking, the
+// pointer events are reported to the application, and an error is returned.
+func (t *Terminal) SelectRange(startRow, startCol, endRow, endCol int) error {
+	if startRow > endRow || (startRow == endRow && startCol > endCol) {
+		return fmt.Errorf("vte: selection end %d:%d precedes start %d:%d", endRow, endCol, startRow, startCol)
+	}
+
+	cols := t.GetColumnCount()
+	if startCol < 0 || startCol >= cols || endCol < 0 || endCol >= cols {
+		return fmt.Errorf("vte: selection column is out of range 0-%d", cols-1)
+	}
+
+	first, last := t.bufferRows()
+	if startRow < first || endRow > last {
+		return fmt.Errorf("vte: selection row is out of range %d-%d", first, last)
+	}
+
+	widget := C.toGtkWidget(unsafe.Pointer(t.GObject))
+
+	window := C.selectionEventWindow(widget)
+	if window == nil {
+		return errors.New("vte: terminal is not realized")
+	}
+
+	adj := t.vadjustment()
+	scrolled := C.gtk_adjustment_get_value(adj)
+	defer C.gtk_adjustment_set_value(adj, scrolled)
+
+	// Pressing the button inside the existing selection starts drag-and-drop
+	// rather than a new selection.
+	t.UnselectAll()
+
+	// A point in the left half of the cell selects from the beginning of the
+	// cell, and a point in the right half selects to its end.
+	x, y := t.selectionPoint(startRow, float64(startCol)+0.25)
+	C.selectionSendEvent(widget, window, C.GDK_BUTTON_PRESS, x, y, 0)
+
+	x, y = t.selectionPoint(endRow, float64(endCol)+0.75)
+	C.selectionSendEvent(widget, window, C.GDK_MOTION_NOTIFY, x, y, C.GDK_BUTTON1_MASK)
+	C.selectionSendEvent(widget, window, C.GDK_BUTTON_RELEASE, x, y, C.GDK_BUTTON1_MASK)
+
+	if !t.HasSelection() {
+		return errors.New("vte: selection was not made")
+	}
+
+	t.trackSelection(startRow, startCol, endRow, endCol)
+	return nil
+}
+
+// GetSelectionBounds returns bounds of the selection, in the same form as
+// accepted by [Terminal.SelectRange]. If nothing is selected, ok is false.
+//
+// VTE provides no public API to query bounds of the selection, so they are
+// only known for the selection made with [Terminal.SelectRange] or
+// [Terminal.SelectAll]. If the selection has been changed since, e.g. by the
+// user with the mouse, ok is false as well.
+func (t *Terminal) GetSelectionBounds() (startRow, startCol, endRow, endCol int, ok bool) {
+	s, ok := t.getData(terminalDataSelection).(*selectionState)
+	if !ok || !t.HasSelection() || t.GetTextSelected(FORMAT_TEXT) != s.text {
+		return 0, 0, 0, 0, false
+	}
+	return s.startRow, s.startCol, s.endRow, s.endCol, true
+}
+
+// trackSelection records bounds of the selection just made.
+func (t *Terminal) trackSelection(startRow, startCol, endRow, endCol int) {
+	t.setData(terminalDataSelection, &selectionState{
+		startRow: startRow,
+		startCol: startCol,
+		endRow:   endRow,
+		endCol:   endCol,
+		text:     t.GetTextSelected(FORMAT_TEXT),
+	})
+}
+
+// selectionPoint scrolls the terminal so that row is visible, and returns
+// widget coordinates of the point at col of row.
+func (t *Terminal) selectionPoint(row int, col float64) (x, y C.double) {
+	var (
+		adj   = t.vadjustment()
+		scale = t.scrollScale()
+	)
+
+	// Value is clamped by the adjustment, so that the last page is not
+	// scrolled past.
+	C.gtk_adjustment_set_value(adj, C.gdouble(float64(row)*scale))
+	first := int(math.Round(float64(C.gtk_adjustment_get_value(adj)) / scale))
+
+	return t.cellPoint(col, float64(row-first)+0.5)
+}
+
+// bufferRows returns the first and the last row of the terminal buffer,
+// including the scrollback.
+func (t *Terminal) bufferRows() (first, last int) {
+	var (
+		adj   = t.vadjustment()
+		scale = t.scrollScale()
+	)
+
+	first = int(math.Round(float64(C.gtk_adjustment_get_lower(adj)) / scale))
+	last = int(math.Round(float64(C.gtk_adjustment_get_upper(adj))/scale)) - 1
+	return first, last
+}
+
+// vadjustment returns the vertical adjustment of the terminal.
+func (t *Terminal) vadjustment() *C.GtkAdjustment {
+	widget := C.toGtkWidget(unsafe.Pointer(t.GObject))
+	return C.gtk_scrollable_get_vadjustment((*C.GtkScrollable)(unsafe.Pointer(widget)))
+}
+
+// scrollScale returns the number of adjustment units per row.
+func (t *Terminal) scrollScale() float64 {
+	if h := t.GetCharHeight(); h > 0 && t.GetScrollUnit() == SCROLL_UNIT_PIXELS {
+		return float64(h)
+	}
+	return 1
+}
@@ -0,0 +1,49 @@
+#include <gtk/gtk.h>
+
+// selectionEventWindow returns the input window that receives pointer events
+// of widget, or NULL if widget is not realized.
+static GdkWindow *selectionEventWindow(GtkWidget *widget) {
+    GdkWindow *parent = gtk_widget_get_window(widget);
+    if (parent == NULL || !gtk_widget_get_realized(widget)) {
+        return NULL;
+    }
+
+    for (GList *l = gdk_window_peek_children(parent); l != NULL; l = l->next) {
+        gpointer data = NULL;
+        gdk_window_get_user_data(GDK_WINDOW(l->data), &data);
+        if (data == widget) {
+            return GDK_WINDOW(l->data);
+        }
+    }
+
+    return NULL;
+}
+
+// selectionSendEvent delivers synthesized event of the primary pointer button
+// at x and y to widget.
+static void selectionSendEvent(GtkWidget *widget, GdkWindow *window, GdkEventType type, double x, double y, GdkModifierType state) {
+    GdkEvent *event = gdk_event_new(type);
+    GdkSeat *seat = gdk_display_get_default_seat(gdk_window_get_display(window));
+
+    if (type == GDK_MOTION_NOTIFY) {
+        event->motion.window = g_object_ref(window);
+        event->motion.time = GDK_CURRENT_TIME;
+        event->motion.x = x;
+        event->motion.y = y;
+        event->motion.state = state;
+    } else {
+        event->button.window = g_object_ref(window);
+        event->button.time = GDK_CURRENT_TIME;
+        event->button.x = x;
+        event->button.y = y;
+        event->button.state = state;
+        event->button.button = GDK_BUTTON_PRIMARY;
+    }
+
+    if (seat != NULL) {
+        gdk_event_set_device(event, gdk_seat_get_pointer(seat));
+    }
+
+    gtk_widget_event(widget, event);
+    gdk_event_free(event);
+}
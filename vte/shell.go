@@ -0,0 +1,168 @@
+package vte
+
+import (
+	"time"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// CommandStarted represents a command entered at the shell prompt that is
+// about to be executed. It is reported by [Terminal.ConnectCommandStarted].
+type CommandStarted struct {
+	// Row of the cursor when the command started. Row is counted from the
+	// beginning of the scrollback buffer.
+	StartRow int
+
+	// Time when the command started.
+	Time time.Time
+
+	// Working directory of the shell as reported by OSC 7, or an empty string
+	// ("") if the shell does not report it.
+	Directory string
+}
+
+// CommandFinished represents a command entered at the shell prompt that has
+// returned. It is reported by [Terminal.ConnectCommandFinished].
+type CommandFinished struct {
+	// Row of the cursor when the command started. Row is counted from the
+	// beginning of the scrollback buffer.
+	StartRow int
+
+	// Row of the cursor when the command finished. Row is counted from the
+	// beginning of the scrollback buffer.
+	EndRow int
+
+	// Exit code of the command, or -1 if the shell did not report it.
+	ExitCode int
+
+	// Time elapsed between start and finish of the command.
+	Duration time.Duration
+
+	// Working directory of the shell when the command started.
+	Directory string
+}
+
+// commandTracker turns shell integration termprops into command lifecycle
+// events.
+type commandTracker struct {
+	running  bool
+	started  *CommandStarted
+	start    func(*Terminal, *CommandStarted)
+	finished func(*Terminal, *CommandFinished)
+}
+
+// handle processes the change of termprop prop. It must be called inside the
+// callback of the "termprop-changed" signal, since shell integration
+// termprops are ephemeral.
+//
+// VTE stops processing input to emit ephemeral termprops, so cursor position
+// is sampled immediately: it is the position where the shell integration
+// sequence was received, even if more output was fed in the same batch.
+// Callbacks may use arbitrary terminal API, which is not allowed during the
+// signal emission, so events are delivered from an idle callback.
+func (c *commandTracker) handle(term *Terminal, prop TermProp) {
+	switch prop {
+	case TERMPROP_SHELL_PREEXEC:
+		if c.running {
+			return
+		}
+
+		_, row := term.GetCursorPosition()
+		started := &CommandStarted{
+			StartRow:  row,
+			Time:      time.Now(),
+			Directory: commandDirectory(term),
+		}
+		c.running = true
+		c.started = started
+
+		if c.start != nil {
+			glib.IdleAdd(func() {
+				c.start(term, started)
+			})
+		}
+
+	case TERMPROP_SHELL_POSTEXEC, TERMPROP_SHELL_PRECMD:
+		if !c.running {
+			return
+		}
+
+		exitCode := -1
+		if prop == TERMPROP_SHELL_POSTEXEC {
+			exitCode = commandExitCode(term)
+		}
+
+		_, endRow := term.GetCursorPosition()
+		started := c.started
+		finished := &CommandFinished{
+			StartRow:  started.StartRow,
+			EndRow:    endRow,
+			ExitCode:  exitCode,
+			Duration:  time.Since(started.Time),
+			Directory: started.Directory,
+		}
+		c.running = false
+		c.started = nil
+
+		if c.finished != nil {
+			glib.IdleAdd(func() {
+				c.finished(term, finished)
+			})
+		}
+	}
+}
+
+// commandDirectory returns path of the current directory URI, or an empty
+// string ("") if it is unset.
+func commandDirectory(term *Terminal) string {
+	uri, err := term.GetTermPropURI(TERMPROP_CURRENT_DIRECTORY_URI)
+	if err != nil {
+		return ""
+	}
+	return uri.Path
+}
+
+// commandExitCode returns value of [TERMPROP_SHELL_POSTEXEC], or -1 if it is
+// unset.
+func commandExitCode(term *Terminal) int {
+	info, err := QueryTermProp(TERMPROP_SHELL_POSTEXEC)
+	if err != nil {
+		return -1
+	}
+
+	switch info.Type {
+	case PROPERTY_INT:
+		if v, err := term.GetTermPropInt(TERMPROP_SHELL_POSTEXEC); err == nil {
+			return int(v)
+		}
+	case PROPERTY_UINT:
+		if v, err := term.GetTermPropUint(TERMPROP_SHELL_POSTEXEC); err == nil {
+			return int(v)
+		}
+	}
+
+	return -1
+}
+
+// ConnectCommandStarted calls callback when the shell is about to execute the
+// command entered at the prompt.
+//
+// The shell must support shell integration, i.e. set termprops
+// [TERMPROP_SHELL_PREEXEC], [TERMPROP_SHELL_POSTEXEC] and
+// [TERMPROP_SHELL_PRECMD]. The callback is invoked from an idle callback of
+// the main loop, after the termprop has changed.
+func (t *Terminal) ConnectCommandStarted(callback func(t *Terminal, cmd *CommandStarted)) glib.SignalHandle {
+	tracker := &commandTracker{start: callback}
+	return t.ConnectTermPropChanged(tracker.handle)
+}
+
+// ConnectCommandFinished calls callback when the command entered at the shell
+// prompt has returned.
+//
+// If the shell does not report the exit code via [TERMPROP_SHELL_POSTEXEC],
+// the command is considered finished when the shell is going to prompt, and
+// exit code is -1. See also [Terminal.ConnectCommandStarted].
+func (t *Terminal) ConnectCommandFinished(callback func(t *Terminal, cmd *CommandFinished)) glib.SignalHandle {
+	tracker := &commandTracker{finished: callback}
+	return t.ConnectTermPropChanged(tracker.handle)
+}
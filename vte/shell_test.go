@@ -0,0 +1,79 @@
+package vte_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gotk3/gotk3/gtk"
+	"github.com/shelepuginivan/gotk3-vte/vte"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminal_CommandLifecycle(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+	term.SetEnableLegacyOSC777(true)
+
+	var (
+		started  *vte.CommandStarted
+		finished *vte.CommandFinished
+	)
+
+	term.ConnectCommandStarted(func(_ *vte.Terminal, cmd *vte.CommandStarted) {
+		started = cmd
+	})
+
+	term.ConnectCommandFinished(func(_ *vte.Terminal, cmd *vte.CommandFinished) {
+		finished = cmd
+		gtk.MainQuit()
+	})
+
+	term.Feed("$ \x1b]777;preexec\x1b\\\r\noutput\r\nmore output\r\n\x1b]777;precmd\x1b\\")
+
+	gtk.Main()
+
+	if assert.NotNil(t, started) && assert.NotNil(t, finished) {
+		assert.Equal(t, started.StartRow, finished.StartRow)
+		assert.GreaterOrEqual(t, finished.EndRow, finished.StartRow)
+		assert.Equal(t, -1, finished.ExitCode)
+		assert.GreaterOrEqual(t, finished.Duration, time.Duration(0))
+	}
+}
+
+func TestTerminal_CommandLifecycleRows(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+	term.SetEnableLegacyOSC777(true)
+
+	var (
+		started  *vte.CommandStarted
+		finished *vte.CommandFinished
+	)
+
+	term.ConnectCommandStarted(func(_ *vte.Terminal, cmd *vte.CommandStarted) {
+		started = cmd
+	})
+
+	term.ConnectCommandFinished(func(_ *vte.Terminal, cmd *vte.CommandFinished) {
+		finished = cmd
+		gtk.MainQuit()
+	})
+
+	// Whole lifecycle and the following prompt are fed at once, so rows must
+	// be sampled when the sequences are received.
+	term.Feed(
+		"first\r\nsecond\r\n$ \x1b]777;preexec\x1b\\" +
+			"\r\none\r\ntwo\r\nthree\r\n" +
+			"\x1b]777;precmd\x1b\\$ \r\n$ ",
+	)
+
+	gtk.Main()
+
+	if assert.NotNil(t, started) && assert.NotNil(t, finished) {
+		assert.Equal(t, 2, started.StartRow)
+		assert.Equal(t, 2, finished.StartRow)
+		assert.Equal(t, 6, finished.EndRow)
+	}
+}
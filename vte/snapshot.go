@@ -0,0 +1,345 @@
+package vte
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gotk3/gotk3/gdk"
+)
+
+// Cell represents a single character cell of the [Snapshot].
+type Cell struct {
+	// Runes of the cell. The first rune is the base character, the rest are
+	// combining characters attached to it.
+	Runes []rune
+
+	// Foreground color of the cell, or nil if the default color is used.
+	Foreground *gdk.RGBA
+
+	// Background color of the cell, or nil if the default color is used.
+	//
+	// Cells in reverse video are exported by VTE with Foreground and Background
+	// already swapped, see Reverse.
+	Background *gdk.RGBA
+
+	Bold          bool
+	Italic        bool
+	Underline     bool
+	Strikethrough bool
+	Overline      bool
+	Blink         bool
+
+	// Reverse reports whether the cell is in reverse video. VTE does not
+	// export this attribute, so it is detected by colors: the cell is
+	// considered reversed if its foreground is the default background color
+	// of the terminal, and its background is set. Hence text explicitly drawn
+	// with the default background color on a colored background is reported
+	// as reversed too.
+	Reverse bool
+
+	// URI of the hyperlink (OSC 8 escape sequence) of the cell, or an empty
+	// string ("") if the cell is not a part of a hyperlink.
+	Hyperlink string
+}
+
+// String returns text of the cell.
+func (c Cell) String() string {
+	return string(c.Runes)
+}
+
+// Snapshot represents contents of the [Terminal] as a grid of cells.
+type Snapshot struct {
+	Rows [][]Cell
+}
+
+// String returns text of the snapshot. Rows are separated by newlines.
+func (s *Snapshot) String() string {
+	var sb strings.Builder
+
+	for i, row := range s.Rows {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, cell := range row {
+			sb.WriteString(cell.String())
+		}
+	}
+
+	return sb.String()
+}
+
+// Snapshot returns contents of the visible part of the terminal as a grid of
+// cells with their attributes.
+//
+// Snapshot is built from the HTML export of the terminal, see
+// [Terminal.GetText] and [FORMAT_HTML].
+func (t *Terminal) Snapshot() (*Snapshot, error) {
+	text, err := t.GetText(FORMAT_HTML)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := parseSnapshotHTML(text)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.detectReverse(t.paletteState().background)
+	return snapshot, nil
+}
+
+// detectReverse marks cells in reverse video, given the default background
+// color of the terminal. See [Cell.Reverse].
+func (s *Snapshot) detectReverse(background Color) {
+	bg := background.String()
+
+	for _, row := range s.Rows {
+		for i := range row {
+			cell := &row[i]
+			cell.Reverse = cell.Foreground != nil &&
+				cell.Background != nil &&
+				ColorFromGdk(cell.Foreground).String() == bg
+		}
+	}
+}
+
+// snapshotTag is an element on the stack of open tags of the HTML export.
+type snapshotTag struct {
+	name string
+	attr Cell
+}
+
+// parseSnapshotHTML parses the HTML export of the terminal into [Snapshot].
+func parseSnapshotHTML(s string) (*Snapshot, error) {
+	var (
+		snapshot = &Snapshot{Rows: [][]Cell{nil}}
+		stack    = []snapshotTag{{}}
+	)
+
+	for len(s) > 0 {
+		if s[0] != '<' {
+			end := strings.IndexByte(s, '<')
+			if end == -1 {
+				end = len(s)
+			}
+			snapshot.appendText(html.UnescapeString(s[:end]), stack[len(stack)-1].attr)
+			s = s[end:]
+			continue
+		}
+
+		end := strings.IndexByte(s, '>')
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated tag %q", s)
+		}
+
+		name, closing, attrs := parseSnapshotTag(s[1:end])
+		s = s[end+1:]
+
+		switch {
+		case name == "br":
+			snapshot.Rows = append(snapshot.Rows, nil)
+		case name == "pre":
+			// The whole export is wrapped in <pre>, it carries no attributes.
+		case closing:
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		default:
+			attr := stack[len(stack)-1].attr
+			applySnapshotTag(&attr, name, attrs)
+			stack = append(stack, snapshotTag{name: name, attr: attr})
+		}
+	}
+
+	// Export ends with a newline, which does not start a new row.
+	if n := len(snapshot.Rows); n > 1 && len(snapshot.Rows[n-1]) == 0 {
+		snapshot.Rows = snapshot.Rows[:n-1]
+	}
+
+	return snapshot, nil
+}
+
+// appendText appends cells for every character of text with attributes attr.
+func (s *Snapshot) appendText(text string, attr Cell) {
+	for _, r := range text {
+		row := &s.Rows[len(s.Rows)-1]
+
+		switch {
+		case r == '\n':
+			s.Rows = append(s.Rows, nil)
+		case r == '\r':
+		case isCombining(r) && len(*row) > 0:
+			last := &(*row)[len(*row)-1]
+			last.Runes = append(last.Runes, r)
+		default:
+			cell := attr
+			cell.Runes = []rune{r}
+			*row = append(*row, cell)
+		}
+	}
+}
+
+// isCombining reports whether r is attached to the preceding character.
+func isCombining(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) ||
+		r == '\u200d' ||
+		unicode.Is(unicode.Variation_Selector, r)
+}
+
+// parseSnapshotTag parses contents of the HTML tag between angle brackets.
+func parseSnapshotTag(s string) (name string, closing bool, attrs map[string]string) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "/")
+
+	if strings.HasPrefix(s, "/") {
+		closing = true
+		s = s[1:]
+	}
+
+	end := strings.IndexFunc(s, unicode.IsSpace)
+	if end == -1 {
+		end = len(s)
+	}
+	name = strings.ToLower(s[:end])
+	s = s[end:]
+
+	attrs = make(map[string]string)
+
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return name, closing, attrs
+		}
+
+		end := strings.IndexAny(s, "= \t\n")
+		if end == -1 {
+			attrs[strings.ToLower(s)] = ""
+			return name, closing, attrs
+		}
+
+		key := strings.ToLower(s[:end])
+		s = strings.TrimLeftFunc(s[end:], unicode.IsSpace)
+
+		if !strings.HasPrefix(s, "=") {
+			attrs[key] = ""
+			continue
+		}
+		s = strings.TrimLeftFunc(s[1:], unicode.IsSpace)
+
+		var value string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			quote := s[0]
+			end := strings.IndexByte(s[1:], quote)
+			if end == -1 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else {
+			end := strings.IndexFunc(s, unicode.IsSpace)
+			if end == -1 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+
+		attrs[key] = html.UnescapeString(value)
+	}
+}
+
+// applySnapshotTag updates attr according to the opening tag name with
+// attributes attrs.
+func applySnapshotTag(attr *Cell, name string, attrs map[string]string) {
+	switch name {
+	case "b", "strong":
+		attr.Bold = true
+	case "i", "em":
+		attr.Italic = true
+	case "u":
+		attr.Underline = true
+	case "s", "strike", "del":
+		attr.Strikethrough = true
+	case "blink":
+		attr.Blink = true
+	case "a":
+		attr.Hyperlink = attrs["href"]
+	case "font":
+		if color, ok := parseSnapshotColor(attrs["color"]); ok {
+			attr.Foreground = color
+		}
+	}
+
+	style, ok := attrs["style"]
+	if !ok {
+		return
+	}
+
+	for _, decl := range strings.Split(style, ";") {
+		property, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+
+		property = strings.ToLower(strings.TrimSpace(property))
+		value = strings.ToLower(strings.TrimSpace(value))
+
+		switch property {
+		case "color":
+			if color, ok := parseSnapshotColor(value); ok {
+				attr.Foreground = color
+			}
+		case "background-color", "background":
+			if color, ok := parseSnapshotColor(value); ok {
+				attr.Background = color
+			}
+		case "font-weight":
+			attr.Bold = value == "bold" || value == "bolder"
+		case "font-style":
+			attr.Italic = value == "italic" || value == "oblique"
+		case "text-decoration", "text-decoration-line":
+			for _, line := range strings.Fields(value) {
+				switch line {
+				case "underline":
+					attr.Underline = true
+				case "line-through":
+					attr.Strikethrough = true
+				case "overline":
+					attr.Overline = true
+				case "blink":
+					attr.Blink = true
+				}
+			}
+		}
+	}
+}
+
+// parseSnapshotColor parses color in the "#rgb" or "#rrggbb" form.
+func parseSnapshotColor(s string) (*gdk.RGBA, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+
+	if len(s) != 6 {
+		return nil, false
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+
+	return gdk.NewRGBA(
+		float64(v>>16&0xff)/0xff,
+		float64(v>>8&0xff)/0xff,
+		float64(v&0xff)/0xff,
+		1,
+	), true
+}
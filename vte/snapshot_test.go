@@ -0,0 +1,97 @@
+package vte
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseSnapshotHTML(t *testing.T) {
+	snapshot, err := parseSnapshotHTML(
+		`<pre>plain <b>bold</b> <i><u style="text-decoration-style:single">iu</u></i><br>` +
+			`<span style="background-color:#0000FF"><font color="#FF0000">red</font></span><br>` +
+			`<strike>s</strike><blink>b</blink><span style="text-decoration-line:overline">o</span><br>` +
+			`&lt;&amp;&gt; e&#769;<br></pre>`,
+	)
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Rows, 4)
+	assert.Equal(t, "plain bold iu\nred\nsbo\n<&> e\u0301", snapshot.String())
+
+	row := snapshot.Rows[0]
+	assert.Len(t, row, 13)
+	assert.False(t, row[0].Bold)
+	assert.True(t, row[6].Bold)
+	assert.False(t, row[10].Bold)
+	assert.True(t, row[11].Italic)
+	assert.True(t, row[11].Underline)
+	assert.Nil(t, row[0].Foreground)
+	assert.Nil(t, row[0].Background)
+
+	row = snapshot.Rows[1]
+	assert.Equal(t, []float64{1, 0, 0, 1}, row[0].Foreground.Floats())
+	assert.Equal(t, []float64{0, 0, 1, 1}, row[0].Background.Floats())
+
+	row = snapshot.Rows[2]
+	assert.True(t, row[0].Strikethrough)
+	assert.True(t, row[1].Blink)
+	assert.True(t, row[2].Overline)
+	assert.False(t, row[2].Blink)
+
+	row = snapshot.Rows[3]
+	assert.Len(t, row, 5)
+	assert.Equal(t, []rune{'e', '\u0301'}, row[4].Runes)
+
+	t.Run("Newlines", func(t *testing.T) {
+		snapshot, err := parseSnapshotHTML("<pre>a\nb\n</pre>")
+		assert.NoError(t, err)
+		assert.Len(t, snapshot.Rows, 2)
+		assert.Equal(t, "a\nb", snapshot.String())
+	})
+
+	t.Run("Hyperlink", func(t *testing.T) {
+		snapshot, err := parseSnapshotHTML(`<pre><a href="https://example.com/?a=1&amp;b=2">x</a>y</pre>`)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/?a=1&b=2", snapshot.Rows[0][0].Hyperlink)
+		assert.Equal(t, "", snapshot.Rows[0][1].Hyperlink)
+	})
+
+	t.Run("Unterminated tag", func(t *testing.T) {
+		snapshot, err := parseSnapshotHTML("<pre>text<b")
+		assert.Nil(t, snapshot)
+		assert.Error(t, err)
+	})
+}
+
+func Test_parseSnapshotColor(t *testing.T) {
+	color, ok := parseSnapshotColor("#FF8000")
+	assert.True(t, ok)
+	assert.InDeltaSlice(t, []float64{1, 0.502, 0, 1}, color.Floats(), 0.001)
+
+	color, ok = parseSnapshotColor("#0f0")
+	assert.True(t, ok)
+	assert.Equal(t, []float64{0, 1, 0, 1}, color.Floats())
+
+	_, ok = parseSnapshotColor("red")
+	assert.False(t, ok)
+
+	_, ok = parseSnapshotColor("#GGGGGG")
+	assert.False(t, ok)
+}
+
+func TestSnapshot_detectReverse(t *testing.T) {
+	snapshot, err := parseSnapshotHTML(
+		`<pre>a<span style="background-color:#C0C0C0"><font color="#000000">b</font></span>` +
+			`<font color="#000000">c</font>` +
+			`<span style="background-color:#FF0000"><font color="#000000">d</font></span></pre>`,
+	)
+	assert.NoError(t, err)
+
+	snapshot.detectReverse(Color{A: 1})
+
+	var reverse []bool
+	for _, cell := range snapshot.Rows[0] {
+		reverse = append(reverse, cell.Reverse)
+	}
+
+	assert.Equal(t, []bool{false, true, false, true}, reverse)
+}
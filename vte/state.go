@@ -0,0 +1,58 @@
+package vte
+
+// #include <glib-object.h>
+// #include <vte/vte.h>
+// #include "glib.go.h"
+// #include "exec.go.h"
+import "C"
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// Keys of the Go values associated with [Terminal].
+const (
+	terminalDataSearchRegex = "gotk3-vte-search-regex"
+	terminalDataPalette     = "gotk3-vte-palette"
+	terminalDataAppearance  = "gotk3-vte-appearance"
+	terminalDataSelection   = "gotk3-vte-selection"
+)
+
+// setData associates value with the terminal under key. Previously associated
+// value is released. Value is released when the terminal is finalized as
+// well. If value is nil, association is removed.
+func (t *Terminal) setData(key string, value any) {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+
+	obj := (*C.GObject)(unsafe.Pointer(t.native()))
+
+	if value == nil {
+		C.g_object_set_data(obj, ckey, nil)
+		return
+	}
+
+	handle := cgo.NewHandle(value)
+	C.g_object_set_data_full(
+		obj,
+		ckey,
+		C.uintptrToGpointer(C.uintptr_t(handle)),
+		C.GDestroyNotify(C.handleDestroy),
+	)
+}
+
+// getData returns value associated with the terminal under key, or nil if
+// there is no such value.
+func (t *Terminal) getData(key string) any {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+
+	obj := (*C.GObject)(unsafe.Pointer(t.native()))
+
+	data := C.g_object_get_data(obj, ckey)
+	if data == nil {
+		return nil
+	}
+
+	return cgo.Handle(C.gpointerToUintptr(data)).Value()
+}
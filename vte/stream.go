@@ -0,0 +1,66 @@
+package vte
+
+// #cgo pkg-config: gio-unix-2.0
+//
+// #include <gio/gio.h>
+// #include <gio/gunixoutputstream.h>
+import "C"
+import (
+	"io"
+	"os"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// writerStream adapts [io.Writer] to GOutputStream.
+//
+// Data written to the stream is sent through a pipe and copied to the writer
+// in a separate goroutine, so that the writer is not called from C code.
+type writerStream struct {
+	stream *C.GOutputStream
+	pw     *os.File
+	done   chan error
+}
+
+// newWriterStream returns a GOutputStream that writes to w. If w returns an
+// error, cancellable is cancelled to interrupt the ongoing write operation.
+//
+// The stream must be closed with [writerStream.Close].
+func newWriterStream(w io.Writer, cancellable *glib.Cancellable) (*writerStream, error) {
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &writerStream{
+		stream: C.g_unix_output_stream_new(C.int(pw.Fd()), C.FALSE),
+		pw:     pw,
+		done:   make(chan error, 1),
+	}
+
+	go func() {
+		defer pr.Close()
+
+		_, err := io.Copy(w, pr)
+		if err != nil {
+			// Drain the pipe, so that the writing side is not blocked until it
+			// notices the cancellation.
+			cancellable.Cancel()
+			io.Copy(io.Discard, pr)
+		}
+
+		s.done <- err
+	}()
+
+	return s, nil
+}
+
+// Close closes the stream and waits until all written data is copied to the
+// writer. It returns an error returned by the writer, if any.
+func (s *writerStream) Close() error {
+	C.g_output_stream_close(s.stream, nil, nil)
+	C.g_object_unref(C.gpointer(unsafe.Pointer(s.stream)))
+	s.pw.Close()
+	return <-s.done
+}
@@ -13,8 +13,10 @@ package vte
 // #include "vte.go.h"
 import "C"
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"unicode/utf8"
 	"unsafe"
 
@@ -28,6 +30,21 @@ import (
 // MatchHandle represents a tag associated with the [Regex].
 type MatchHandle int
 
+// Geometry represents size of [Terminal] grid and its cells.
+type Geometry struct {
+	// Number of columns in the terminal.
+	Columns int
+
+	// Number of rows in the terminal.
+	Rows int
+
+	// Width of a character cell in pixels.
+	CharWidth int
+
+	// Height of a character cell in pixels.
+	CharHeight int
+}
+
 // Terminal is a wrapper around VteTerminal.
 type Terminal struct {
 	gtk.Widget
@@ -90,6 +107,28 @@ func (t *Terminal) CopyPrimary() {
 	C.vte_terminal_copy_primary(t.native())
 }
 
+// SelectAll selects all text within the terminal, including the scrollback
+// buffer. To select a part of the contents, use [Terminal.SelectRange].
+func (t *Terminal) SelectAll() {
+	C.vte_terminal_select_all(t.native())
+
+	first, last := t.bufferRows()
+	t.trackSelection(first, 0, last, t.GetColumnCount()-1)
+}
+
+// UnselectAll clears the current selection.
+func (t *Terminal) UnselectAll() {
+	C.vte_terminal_unselect_all(t.native())
+	t.setData(terminalDataSelection, nil)
+}
+
+// HasSelection reports whether the terminal has selected text. Selected text
+// can be retrieved with [Terminal.GetTextSelected], and its position with
+// [Terminal.GetSelectionBounds].
+func (t *Terminal) HasSelection() bool {
+	return goBool(C.vte_terminal_get_has_selection(t.native()))
+}
+
 // PasteClipboard pastes contents of clipboard to the terminal.
 func (t *Terminal) PasteClipboard() {
 	C.vte_terminal_paste_clipboard(t.native())
@@ -144,10 +183,171 @@ func (t *Terminal) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+// GetText returns text of the visible part of the terminal in the specified
+// format.
+//
+// If format is [FORMAT_HTML], the text is wrapped in a <pre> element and
+// character attributes are exported as HTML tags.
+func (t *Terminal) GetText(format Format) (string, error) {
+	cstr := C.vte_terminal_get_text_format(t.native(), C.VteFormat(format))
+	if cstr == nil {
+		return "", errNilPointer("vte_terminal_get_text_format")
+	}
+	defer C.free(unsafe.Pointer(cstr))
+	return goString(cstr), nil
+}
+
+// GetTextRange returns text of the terminal between the cell at startRow and
+// startCol and the cell at endRow and endCol in the specified format.
+//
+// Rows are counted from the beginning of the scrollback buffer, so that text
+// that has scrolled out of the visible part of the terminal can be retrieved.
+func (t *Terminal) GetTextRange(format Format, startRow, startCol, endRow, endCol int) (string, error) {
+	var length C.gsize
+
+	cstr := C.vte_terminal_get_text_range_format(
+		t.native(),
+		C.VteFormat(format),
+		C.long(startRow),
+		C.long(startCol),
+		C.long(endRow),
+		C.long(endCol),
+		&length,
+	)
+	if cstr == nil {
+		return "", errNilPointer("vte_terminal_get_text_range_format")
+	}
+	defer C.free(unsafe.Pointer(cstr))
+	return C.GoStringN(cstr, C.int(length)), nil
+}
+
+// GetTextSelected returns selected text in the specified format. If nothing
+// is selected, an empty string is returned.
+func (t *Terminal) GetTextSelected(format Format) string {
+	cstr := C.vte_terminal_get_text_selected(t.native(), C.VteFormat(format))
+	if cstr == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(cstr))
+	return goString(cstr)
+}
+
+// WriteContents writes contents of the terminal, including the scrollback
+// buffer, to w.
+//
+// Unlike copying the whole text with [Terminal.CopyClipboardFormat], the
+// contents are written as-is and the clipboard is left intact.
+func (t *Terminal) WriteContents(w io.Writer, flags WriteFlags) error {
+	return t.WriteContentsContext(context.Background(), w, flags)
+}
+
+// WriteContentsContext is like [Terminal.WriteContents], but writing is
+// interrupted when ctx is done. In that case, ctx.Err() is returned.
+func (t *Terminal) WriteContentsContext(ctx context.Context, w io.Writer, flags WriteFlags) error {
+	cancellable, err := glib.CancellableNew()
+	if err != nil {
+		return err
+	}
+
+	stop := context.AfterFunc(ctx, cancellable.Cancel)
+	defer stop()
+
+	stream, err := newWriterStream(w, cancellable)
+	if err != nil {
+		return err
+	}
+
+	var (
+		gerr *C.GError
+		c    = C.toCancellable(unsafe.Pointer(cancellable.GObject))
+		f    = C.VteWriteFlags(flags)
+	)
+
+	success := C.vte_terminal_write_contents_sync(t.native(), stream.stream, f, c, &gerr)
+	if gerr != nil {
+		defer C.g_error_free(gerr)
+	}
+
+	if err := stream.Close(); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !goBool(success) {
+		if gerr == nil {
+			return errFailed("vte_terminal_write_contents_sync")
+		}
+		return errFromGError("vte_terminal_write_contents_sync", gerr)
+	}
+
+	return nil
+}
+
+// GetCursorPosition returns the location of the cursor. Row is counted from
+// the beginning of the scrollback buffer.
+func (t *Terminal) GetCursorPosition() (col, row int) {
+	var ccol, crow C.glong
+	C.vte_terminal_get_cursor_position(t.native(), &ccol, &crow)
+	return int(ccol), int(crow)
+}
+
+// GetColumnCount returns the number of columns in the terminal.
+func (t *Terminal) GetColumnCount() int {
+	return int(C.vte_terminal_get_column_count(t.native()))
+}
+
+// GetRowCount returns the number of visible rows in the terminal.
+func (t *Terminal) GetRowCount() int {
+	return int(C.vte_terminal_get_row_count(t.native()))
+}
+
+// SetSize attempts to change the terminal's size in terms of rows and
+// columns. If the attempt succeeds, the widget will resize itself to the
+// proper size.
+func (t *Terminal) SetSize(cols, rows int) {
+	C.vte_terminal_set_size(t.native(), C.glong(cols), C.glong(rows))
+}
+
+// GetCharWidth returns the width of a character cell in pixels.
+func (t *Terminal) GetCharWidth() int {
+	return int(C.vte_terminal_get_char_width(t.native()))
+}
+
+// GetCharHeight returns the height of a character cell in pixels.
+func (t *Terminal) GetCharHeight() int {
+	return int(C.vte_terminal_get_char_height(t.native()))
+}
+
+// GetGeometry returns size of the terminal grid and its cells.
+//
+// The widget size that fits the grid exactly, excluding padding, is
+// Columns*CharWidth by Rows*CharHeight pixels.
+func (t *Terminal) GetGeometry() *Geometry {
+	return &Geometry{
+		Columns:    t.GetColumnCount(),
+		Rows:       t.GetRowCount(),
+		CharWidth:  t.GetCharWidth(),
+		CharHeight: t.GetCharHeight(),
+	}
+}
+
 // GetTermProp returns termprop by name. It should be called inside the
 // callback of [Terminal.ConnectTermPropChanged] or
 // [Terminal.ConnectAfterTermPropChanged].
+//
+// If the termprop has no value, [ErrTermPropUnset] is returned. Termprops of
+// type [PROPERTY_VALUELESS] have nil value when set, see also
+// [Terminal.IsTermPropSet]. Typed getters such as [Terminal.GetTermPropString]
+// should be preferred when the type of the termprop is known.
 func (t *Terminal) GetTermProp(prop TermProp) (any, error) {
+	info, err := QueryTermProp(prop)
+	if err != nil {
+		return nil, err
+	}
+
 	v, err := glib.ValueAlloc()
 	if err != nil {
 		return nil, err
@@ -158,7 +358,11 @@ func (t *Terminal) GetTermProp(prop TermProp) (any, error) {
 	defer C.free(unsafe.Pointer(cprop))
 
 	if !goBool(C.vte_terminal_get_termprop_value(t.native(), cprop, gvalue)) {
-		return nil, errFailed("vte_terminal_get_termprop_value")
+		return nil, ErrTermPropUnset
+	}
+
+	if info.Type == PROPERTY_VALUELESS {
+		return nil, nil
 	}
 
 	return v.GoValue()
@@ -749,38 +953,100 @@ func (t *Terminal) SetYFill(v bool) {
 
 // Spawn is a convenience function that wraps creating the [Pty] and
 // spawning the child process on it. See [Pty.Spawn] for more information.
-func (t *Terminal) Spawn(cmd *Command) {
-	var ccallID C.gpointer
-	if cmd.OnSpawn != nil {
-		callID := assignCallID(cmd)
-		ccallID = C.uintToGpointer(C.uint(callID))
+//
+// Like [Pty.Spawn], it validates cmd before spawning, so a program name
+// without a slash requires [SPAWN_SEARCH_PATH] to be looked up in PATH.
+//
+// The returned [Process] is notified when the terminal emits the
+// "child-exited" signal, see [Terminal.ConnectChildExited].
+func (t *Terminal) Spawn(cmd *Command) *Process {
+	p := newProcess()
+	unwatch := t.watchProcess(p)
+
+	c := *cmd
+	c.OnSpawn = func(pid int, err error) {
+		if err != nil {
+			unwatch()
+		}
+		p.start(pid, err)
+		if cmd.OnSpawn != nil {
+			cmd.OnSpawn(pid, err)
+		}
+	}
+
+	if c.ctx != nil {
+		commandSetDefaults(&c)
+		p.watchContext(c.ctx, c.Cancellable)
+	}
+
+	t.spawn(&c)
+
+	return p
+}
+
+// watchProcess notifies p when the terminal emits the "child-exited" signal
+// for the first time. If the terminal is destroyed before that, p watches the
+// child process itself. The returned function stops watching.
+func (t *Terminal) watchProcess(p *Process) func() {
+	var exited, destroyed glib.SignalHandle
+
+	unwatch := func() {
+		t.HandlerDisconnect(exited)
+		t.HandlerDisconnect(destroyed)
 	}
 
+	exited = t.ConnectChildExitedStatus(func(t *Terminal, status ExitStatus) {
+		unwatch()
+		p.exit(status)
+	})
+
+	destroyed = t.Connect("destroy", func() {
+		unwatch()
+		p.detach()
+	})
+
+	return unwatch
+}
+
+func (t *Terminal) spawn(cmd *Command) {
+	fds, mapFds, err := spawnPrepare(cmd)
+	if err != nil {
+		if cmd.OnSpawn != nil {
+			cmd.OnSpawn(-1, err)
+		}
+		return
+	}
+
+	handle := newSpawnHandle(cmd)
+
 	var (
-		ptyFlags              = C.VtePtyFlags(cmd.PtyFlags)
-		workdir               = C.CString(cmd.Dir)
-		argv                  = cStringArr(cmd.Args)
-		envv                  = cStringArr(cmd.Env)
-		spawnFlags            = C.GSpawnFlags(cmd.SpawnFlags)
-		childSetup            C.GSpawnChildSetupFunc
-		childSetupData        C.gpointer
-		cTimeout              = C.int(cmd.Timeout.Milliseconds())
-		cCancellable          = C.toCancellable(unsafe.Pointer(cmd.Cancellable.GObject))
-		childSetupDataDestroy C.GDestroyNotify
-		callback              = C.VteTerminalSpawnAsyncCallback(C.terminalSpawnAsyncCallback)
-		userData              = ccallID
+		ptyFlags     = C.VtePtyFlags(cmd.PtyFlags)
+		workdir      = C.CString(cmd.Dir)
+		argv         = cStringArr(cmd.Args)
+		envv         = cStringArr(cmd.Environ())
+		spawnFlags   = C.GSpawnFlags(cmd.SpawnFlags)
+		cTimeout     = C.int(cmd.Timeout.Milliseconds())
+		cCancellable = C.toCancellable(unsafe.Pointer(cmd.Cancellable.GObject))
+		callback     = C.VteTerminalSpawnAsyncCallback(C.terminalSpawnAsyncCallback)
+		userData     = C.uintptrToGpointer(C.uintptr_t(handle))
 	)
 
 	defer C.free(unsafe.Pointer(workdir))
 	defer cStringArrFree(argv)
 	defer cStringArrFree(envv)
 
-	C.vte_terminal_spawn_async(
+	childSetup, childSetupData, childSetupDataDestroy := cSpawnData(handle, cmd.ChildSetup)
+
+	C.vte_terminal_spawn_with_fds_async(
 		t.native(),
 		ptyFlags,
 		workdir,
 		&argv[0],
 		&envv[0],
+		cIntArrPtr(fds),
+		C.int(len(fds)),
+		cIntArrPtr(mapFds),
+		C.int(len(mapFds)),
 		spawnFlags,
 		childSetup,
 		childSetupData,
@@ -792,14 +1058,27 @@ func (t *Terminal) Spawn(cmd *Command) {
 	)
 }
 
+// SpawnContext is like [Terminal.Spawn], but blocks until the command is
+// spawned. See [Pty.SpawnContext] for more information.
+func (t *Terminal) SpawnContext(ctx context.Context, cmd *Command) (int, error) {
+	return spawnContext(ctx, cmd, t.Spawn)
+}
+
 // WatchChild watches pid. When the process exists, the "child-exited" signal
 // will be called with the child's exit status.
 //
 // This method is only required if [Pty] was set with [Terminal.SetPty], and
 // the child process was spawned with [Pty.Spawn]. If [Terminal.Spawn] is used,
 // this is handled automatically.
+//
+// If pid belongs to the [Process] returned by [Pty.Spawn], the process is
+// notified when the "child-exited" signal is emitted.
 func (t *Terminal) WatchChild(pid int) {
 	C.vte_terminal_watch_child(t.native(), C.GPid(pid))
+
+	if p, pending := pendingProcesses.LoadAndDelete(pid); pending {
+		t.watchProcess(p.(*Process))
+	}
 }
 
 // Reset resets as much of the terminal's internal state as possible,
@@ -842,7 +1121,7 @@ func (t *Terminal) SetColors(background, foreground *gdk.RGBA, palette []*gdk.RG
 		bg *C.GdkRGBA
 		fg *C.GdkRGBA
 
-		p    = make([]*C.GdkRGBA, len(palette))
+		p    = make([]C.GdkRGBA, len(palette))
 		size = C.uintToGsize(C.uint(len(palette)))
 	)
 
@@ -854,19 +1133,21 @@ func (t *Terminal) SetColors(background, foreground *gdk.RGBA, palette []*gdk.RG
 		fg = unwrapGdkRGBA(foreground)
 	}
 
+	// VTE expects palette as a contiguous array of GdkRGBA.
 	for i, color := range palette {
-		p[i] = unwrapGdkRGBA(color)
+		p[i] = *unwrapGdkRGBA(color)
 	}
 
 	var cPalette *C.GdkRGBA
 
 	if l > 0 {
-		cPalette = p[0]
+		cPalette = &p[0]
 	} else {
 		cPalette = (*C.GdkRGBA)(C.NULL)
 	}
 
 	C.vte_terminal_set_colors(t.native(), fg, bg, cPalette, size)
+	t.trackColors(background, foreground, palette)
 	return nil
 }
 
@@ -890,6 +1171,9 @@ func (t *Terminal) SetCursorColor(background, foreground *gdk.RGBA) {
 
 	C.vte_terminal_set_color_cursor(t.native(), bg)
 	C.vte_terminal_set_color_cursor_foreground(t.native(), fg)
+
+	s := t.paletteState()
+	s.cursor, s.cursorText = colorFromGdkOrNil(background), colorFromGdkOrNil(foreground)
 }
 
 // SetHighlightColor sets the color for the text which is highlighted.
@@ -912,6 +1196,71 @@ func (t *Terminal) SetHighlightColor(background, foreground *gdk.RGBA) {
 
 	C.vte_terminal_set_color_highlight(t.native(), bg)
 	C.vte_terminal_set_color_highlight_foreground(t.native(), fg)
+
+	s := t.paletteState()
+	s.highlight, s.highlightText = colorFromGdkOrNil(background), colorFromGdkOrNil(foreground)
+}
+
+// SetColorForeground sets the foreground color used to draw normal text.
+// foreground must not be nil.
+func (t *Terminal) SetColorForeground(foreground *gdk.RGBA) {
+	if foreground == nil {
+		return
+	}
+	C.vte_terminal_set_color_foreground(t.native(), unwrapGdkRGBA(foreground))
+	t.paletteState().foreground = ColorFromGdk(foreground)
+}
+
+// SetColorBackground sets the background color for text which does not have
+// a specific background color assigned. Only has effect when no background
+// image is set and when the terminal is not transparent. background must not
+// be nil.
+func (t *Terminal) SetColorBackground(background *gdk.RGBA) {
+	if background == nil {
+		return
+	}
+	C.vte_terminal_set_color_background(t.native(), unwrapGdkRGBA(background))
+	t.paletteState().background = ColorFromGdk(background)
+}
+
+// SetColorBold sets the color used to draw bold text in the default foreground
+// color. If bold is nil then the default color is used.
+func (t *Terminal) SetColorBold(bold *gdk.RGBA) {
+	var b *C.GdkRGBA
+
+	if bold != nil {
+		b = unwrapGdkRGBA(bold)
+	}
+
+	C.vte_terminal_set_color_bold(t.native(), b)
+	t.paletteState().bold = colorFromGdkOrNil(bold)
+}
+
+// SetDefaultColors resets the terminal palette to its default values.
+func (t *Terminal) SetDefaultColors() {
+	C.vte_terminal_set_default_colors(t.native())
+	t.setData(terminalDataPalette, nil)
+}
+
+// SetClearBackground sets whether to paint the background with the background
+// color. The default is true.
+//
+// This function is rarely useful. One use for it is to add a background image
+// to the terminal.
+func (t *Terminal) SetClearBackground(v bool) {
+	C.vte_terminal_set_clear_background(t.native(), gboolean(v))
+}
+
+// GetColorBackgroundForDraw returns the background color, as used by the
+// terminal when drawing the background, which may be different from the color
+// set by [Terminal.SetColorBackground].
+//
+// Note: you must only call this function while handling the GtkWidget::draw
+// signal.
+func (t *Terminal) GetColorBackgroundForDraw() *gdk.RGBA {
+	color := gdk.NewRGBA()
+	C.vte_terminal_get_color_background_for_draw(t.native(), unwrapGdkRGBA(color))
+	return color
 }
 
 // MatchAddRegex adds the regular expression regex to the list of matching
@@ -982,6 +1331,177 @@ func (t *Terminal) MatchCheckEvent(event *gdk.Event) (string, MatchHandle, error
 	return goString(cstr), MatchHandle(handle), nil
 }
 
+// CheckMatchAt checks if the text in and around the visible cell at col and
+// row matches any of the regular expressions previously set using
+// [Terminal.MatchAddRegex]. If a match exists, the text string and handle are
+// returned, and ok is true.
+//
+// Unlike [Terminal.MatchCheckEvent], CheckMatchAt does not require a pointer
+// event, e.g. it can be used to implement keyboard navigation over links.
+func (t *Terminal) CheckMatchAt(col, row int) (text string, handle MatchHandle, ok bool) {
+	var (
+		tag  C.int
+		x, y = t.cellCenter(col, row)
+	)
+
+	cstr := C.vte_terminal_check_match_at(t.native(), x, y, &tag)
+	if cstr == nil {
+		return "", -1, false
+	}
+	defer C.free(unsafe.Pointer(cstr))
+	return goString(cstr), MatchHandle(tag), true
+}
+
+// CheckHyperlinkAt returns URI of the hyperlink (OSC 8 escape sequence) at the
+// visible cell at col and row. If there is no hyperlink, ok is false.
+//
+// Hyperlinks must be allowed with [Terminal.SetAllowHyperlink].
+func (t *Terminal) CheckHyperlinkAt(col, row int) (uri string, ok bool) {
+	x, y := t.cellCenter(col, row)
+
+	cstr := C.vte_terminal_check_hyperlink_at(t.native(), x, y)
+	if cstr == nil {
+		return "", false
+	}
+	defer C.free(unsafe.Pointer(cstr))
+	return goString(cstr), true
+}
+
+// HyperlinkCheckEvent returns URI of the hyperlink (OSC 8 escape sequence) at
+// the position of the event. If there is no hyperlink, ok is false.
+//
+// Hyperlinks must be allowed with [Terminal.SetAllowHyperlink].
+func (t *Terminal) HyperlinkCheckEvent(event *gdk.Event) (uri string, ok bool) {
+	cstr := C.vte_terminal_hyperlink_check_event(t.native(), (*C.GdkEvent)(event.GdkEvent))
+	if cstr == nil {
+		return "", false
+	}
+	defer C.free(unsafe.Pointer(cstr))
+	return goString(cstr), true
+}
+
+// CheckRegexesAt checks if the text in and around the visible cell at col and
+// row matches any of regexes. Every regex that matched is returned along with
+// the matched text, in the order of regexes.
+//
+// Regexes are not required to be added with [Terminal.MatchAddRegex], but
+// they must be created with [REGEX_PURPOSE_MATCH].
+func (t *Terminal) CheckRegexesAt(col, row int, regexes []*Regex, flags RegexMatchFlags) ([]RegexMatch, error) {
+	if len(regexes) == 0 {
+		return nil, nil
+	}
+
+	cRegexes, err := cRegexArr(regexes)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		matches = make([]*C.char, len(regexes))
+		x, y    = t.cellCenter(col, row)
+	)
+
+	C.vte_terminal_check_regex_simple_at(
+		t.native(),
+		x,
+		y,
+		&cRegexes[0],
+		C.gsize(len(cRegexes)),
+		C.guint32(flags),
+		&matches[0],
+	)
+
+	return regexMatches(regexes, matches), nil
+}
+
+// EventCheckRegexes is like [Terminal.CheckRegexesAt], but checks the text in
+// and around the position of the event.
+func (t *Terminal) EventCheckRegexes(event *gdk.Event, regexes []*Regex, flags RegexMatchFlags) ([]RegexMatch, error) {
+	if len(regexes) == 0 {
+		return nil, nil
+	}
+
+	cRegexes, err := cRegexArr(regexes)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*C.char, len(regexes))
+
+	C.vte_terminal_event_check_regex_simple(
+		t.native(),
+		(*C.GdkEvent)(event.GdkEvent),
+		&cRegexes[0],
+		C.gsize(len(cRegexes)),
+		C.guint32(flags),
+		&matches[0],
+	)
+
+	return regexMatches(regexes, matches), nil
+}
+
+// cellCenter returns widget coordinates of the center of the visible cell at
+// col and row.
+func (t *Terminal) cellCenter(col, row int) (x, y C.double) {
+	return t.cellPoint(float64(col)+0.5, float64(row)+0.5)
+}
+
+// cellPoint returns widget coordinates of the point of the visible part of the
+// terminal, measured in cells.
+func (t *Terminal) cellPoint(col, row float64) (x, y C.double) {
+	var (
+		padding C.GtkBorder
+		widget  = C.toGtkWidget(unsafe.Pointer(t.GObject))
+		style   = C.gtk_widget_get_style_context(widget)
+	)
+
+	C.gtk_style_context_get_padding(style, C.gtk_style_context_get_state(style), &padding)
+
+	x = C.double(float64(padding.left) + col*float64(t.GetCharWidth()))
+	y = C.double(float64(padding.top) + row*float64(t.GetCharHeight()))
+	return x, y
+}
+
+// cRegexArr returns an array of pointers to the underlying VteRegex of
+// regexes. Every regex must be created with [REGEX_PURPOSE_MATCH].
+func cRegexArr(regexes []*Regex) ([]*C.VteRegex, error) {
+	cArr := make([]*C.VteRegex, len(regexes))
+
+	for i, regex := range regexes {
+		if regex == nil {
+			return nil, fmt.Errorf("regex must not be nil")
+		}
+
+		if regex.purpose != REGEX_PURPOSE_MATCH {
+			return nil, fmt.Errorf("regex purpose is not match")
+		}
+
+		cArr[i] = regex.ptr
+	}
+
+	return cArr, nil
+}
+
+// regexMatches frees matches and returns [RegexMatch] for every regex that
+// matched.
+func regexMatches(regexes []*Regex, matches []*C.char) []RegexMatch {
+	var result []RegexMatch
+
+	for i, cstr := range matches {
+		if cstr == nil {
+			continue
+		}
+
+		result = append(result, RegexMatch{
+			Regex: regexes[i],
+			Text:  goString(cstr),
+		})
+		C.free(unsafe.Pointer(cstr))
+	}
+
+	return result
+}
+
 // SearchFindNext searches the next string matching the search regex set with
 // [SearchSetRegex].
 func (t *Terminal) SearchFindNext() bool {
@@ -995,12 +1515,20 @@ func (t *Terminal) SearchFindPrev() bool {
 }
 
 // SearchGetRegex returns [Regex] used for search, or nil if it is unset.
+//
+// If regex was set with [Terminal.SearchSetRegex], the same [Regex] is
+// returned, so that its pattern and flags are available.
 func (t *Terminal) SearchGetRegex() *Regex {
 	ptr := C.vte_terminal_search_get_regex(t.native())
 	if ptr == nil {
 		return nil
 	}
-	return wrapRegex(ptr)
+
+	if r, ok := t.getData(terminalDataSearchRegex).(*Regex); ok && r.ptr == ptr {
+		return r
+	}
+
+	return wrapRegex(ptr, REGEX_PURPOSE_SEARCH)
 }
 
 // SearchSetRegex sets [Regex] for search. Use nil to reset regex.
@@ -1010,6 +1538,15 @@ func (t *Terminal) SearchSetRegex(regex *Regex, flags RegexMatchFlags) {
 		r = regex.ptr
 	}
 	C.vte_terminal_search_set_regex(t.native(), r, C.uint(flags))
+
+	// VTE rejects regex that is not created for search, keeping the previous
+	// one.
+	switch {
+	case regex == nil:
+		t.setData(terminalDataSearchRegex, nil)
+	case C.vte_terminal_search_get_regex(t.native()) == r:
+		t.setData(terminalDataSearchRegex, regex)
+	}
 }
 
 // SearchSetWrapAround controls whether [Terminal.SearchFindNext] and
@@ -1049,7 +1586,9 @@ func (t *Terminal) ConnectAfterCellSizeChanged(callback func(t *Terminal, width,
 	return t.ConnectAfter("char-size-changed", t.signalCbUU(callback))
 }
 
-// ConnectChildExited calls callback when the child has exited.
+// ConnectChildExited calls callback when the child has exited. Status is the
+// raw wait status, see [Terminal.ConnectChildExitedStatus] for the decoded
+// one.
 //
 // See [github.com/gotk3/gotk3/glib.Object.Connect] for more information about
 // signal handling.
@@ -1063,6 +1602,18 @@ func (t *Terminal) ConnectAfterChildExited(callback func(t *Terminal, status int
 	return t.ConnectAfter("child-exited", t.signalCbI(callback))
 }
 
+// ConnectChildExitedStatus is like [Terminal.ConnectChildExited], but passes
+// the decoded [ExitStatus] to callback.
+func (t *Terminal) ConnectChildExitedStatus(callback func(t *Terminal, status ExitStatus)) glib.SignalHandle {
+	return t.Connect("child-exited", t.signalCbExitStatus(callback))
+}
+
+// ConnectAfterChildExitedStatus is like [Terminal.ConnectChildExitedStatus],
+// but is invoked after the default handler.
+func (t *Terminal) ConnectAfterChildExitedStatus(callback func(t *Terminal, status ExitStatus)) glib.SignalHandle {
+	return t.ConnectAfter("child-exited", t.signalCbExitStatus(callback))
+}
+
 // ConnectCommit calls callback when the terminal receives input from the user
 // and prepares to send it to the child process.
 //
@@ -1302,6 +1853,15 @@ func (t *Terminal) signalCbI(cb func(*Terminal, int)) any {
 	}
 }
 
+func (t *Terminal) signalCbExitStatus(cb func(*Terminal, ExitStatus)) any {
+	return func(o *glib.Object, i int) {
+		term := WrapTerminal(o)
+		if term != nil {
+			cb(term, ExitStatus(i))
+		}
+	}
+}
+
 func (t *Terminal) signalCbS(cb func(*Terminal, string)) any {
 	return func(o *glib.Object, s string) {
 		term := WrapTerminal(o)
@@ -1,10 +1,15 @@
 package vte_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"math/rand/v2"
-	"os"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/gotk3/gotk3/cairo"
 	"github.com/gotk3/gotk3/gdk"
@@ -351,6 +356,197 @@ func TestTerminal_PropertyYFill(t *testing.T) {
 	assert.Equal(t, false, term.GetYFill())
 }
 
+// feed writes text to the terminal and waits until it is processed.
+func feed(term *vte.Terminal, text string) {
+	gtk.Init(nil)
+
+	handle := term.ConnectContentsChanged(func(_ *vte.Terminal) {
+		gtk.MainQuit()
+	})
+	defer term.HandlerDisconnect(handle)
+
+	term.Feed(text)
+
+	gtk.Main()
+}
+
+func TestTerminal_GetText(t *testing.T) {
+	term := newTerm(t)
+	feed(term, "hello\r\nworld")
+
+	text, err := term.GetText(vte.FORMAT_TEXT)
+	assert.NoError(t, err)
+	assert.Contains(t, text, "hello\nworld")
+
+	html, err := term.GetText(vte.FORMAT_HTML)
+	assert.NoError(t, err)
+	assert.Contains(t, html, "<pre>")
+	assert.Contains(t, html, "hello")
+}
+
+func TestTerminal_GetTextRange(t *testing.T) {
+	term := newTerm(t)
+	feed(term, "first\r\nsecond\r\nthird")
+
+	text, err := term.GetTextRange(vte.FORMAT_TEXT, 1, 0, 1, 5)
+	assert.NoError(t, err)
+	assert.Contains(t, text, "second")
+	assert.NotContains(t, text, "first")
+	assert.NotContains(t, text, "third")
+}
+
+func TestTerminal_GetTextSelected(t *testing.T) {
+	term := newTerm(t)
+	feed(term, "something")
+
+	assert.Equal(t, "", term.GetTextSelected(vte.FORMAT_TEXT))
+}
+
+func TestTerminal_Selection(t *testing.T) {
+	term := newTerm(t)
+	feed(term, "first\r\nsecond")
+
+	assert.False(t, term.HasSelection())
+
+	term.SelectAll()
+	assert.True(t, term.HasSelection())
+	assert.Contains(t, term.GetTextSelected(vte.FORMAT_TEXT), "first\nsecond")
+
+	term.UnselectAll()
+	assert.False(t, term.HasSelection())
+	assert.Equal(t, "", term.GetTextSelected(vte.FORMAT_TEXT))
+}
+
+func TestTerminal_SelectRange(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+	feed(term, "zero\r\none\r\ntwo\r\nthree")
+
+	// Selection is made with pointer events, which require a realized widget.
+	assert.Error(t, term.SelectRange(1, 1, 2, 1))
+
+	win, err := gtk.WindowNew(gtk.WINDOW_TOPLEVEL)
+	assert.NoError(t, err)
+	defer win.Destroy()
+
+	win.Add(term)
+	win.ShowAll()
+	for gtk.EventsPending() {
+		gtk.MainIteration()
+	}
+
+	assert.NoError(t, term.SelectRange(1, 1, 2, 1))
+	assert.Equal(t, "ne\ntw", term.GetTextSelected(vte.FORMAT_TEXT))
+
+	startRow, startCol, endRow, endCol, ok := term.GetSelectionBounds()
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 1, 2, 1}, []int{startRow, startCol, endRow, endCol})
+
+	term.SelectAll()
+	startRow, startCol, _, _, ok = term.GetSelectionBounds()
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 0}, []int{startRow, startCol})
+
+	term.UnselectAll()
+	_, _, _, _, ok = term.GetSelectionBounds()
+	assert.False(t, ok)
+
+	assert.Error(t, term.SelectRange(2, 0, 1, 0))
+	assert.Error(t, term.SelectRange(0, -1, 0, 0))
+	assert.Error(t, term.SelectRange(0, 0, 1000, 0))
+}
+
+func TestTerminal_Size(t *testing.T) {
+	term := newTerm(t)
+
+	term.SetSize(80, 24)
+	assert.Equal(t, 80, term.GetColumnCount())
+	assert.Equal(t, 24, term.GetRowCount())
+
+	term.SetSize(120, 40)
+	assert.Equal(t, 120, term.GetColumnCount())
+	assert.Equal(t, 40, term.GetRowCount())
+
+	assert.Greater(t, term.GetCharWidth(), 0)
+	assert.Greater(t, term.GetCharHeight(), 0)
+
+	expected := &vte.Geometry{
+		Columns:    120,
+		Rows:       40,
+		CharWidth:  term.GetCharWidth(),
+		CharHeight: term.GetCharHeight(),
+	}
+	assert.Equal(t, expected, term.GetGeometry())
+}
+
+func TestTerminal_GetCursorPosition(t *testing.T) {
+	term := newTerm(t)
+
+	col, row := term.GetCursorPosition()
+	assert.Equal(t, 0, col)
+	assert.Equal(t, 0, row)
+
+	feed(term, "first\r\nabc")
+
+	col, row = term.GetCursorPosition()
+	assert.Equal(t, 3, col)
+	assert.Equal(t, 1, row)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestTerminal_WriteContents(t *testing.T) {
+	term := newTerm(t)
+	feed(term, "line 1\r\nline 2\r\nline 3\r\nline 4\r\nline 5\r\nline 6\r\nline 7")
+
+	var buf bytes.Buffer
+	assert.NoError(t, term.WriteContents(&buf, vte.WRITE_DEFAULT))
+
+	for i := 1; i <= 7; i++ {
+		assert.Contains(t, buf.String(), fmt.Sprintf("line %d", i))
+	}
+
+	t.Run("Writer error", func(t *testing.T) {
+		assert.EqualError(t, term.WriteContents(errWriter{}, vte.WRITE_DEFAULT), "write failed")
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var buf bytes.Buffer
+		assert.ErrorIs(t, term.WriteContentsContext(ctx, &buf, vte.WRITE_DEFAULT), context.Canceled)
+	})
+}
+
+func TestTerminal_Snapshot(t *testing.T) {
+	term := newTerm(t)
+	feed(term, "plain \x1b[1mbold\x1b[0m \x1b[31mred\x1b[0m \x1b[7mrev\x1b[0m")
+
+	snapshot, err := term.Snapshot()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(snapshot.String(), "plain bold red rev"))
+
+	if !assert.NotEmpty(t, snapshot.Rows) || !assert.GreaterOrEqual(t, len(snapshot.Rows[0]), 18) {
+		return
+	}
+
+	row := snapshot.Rows[0]
+	assert.False(t, row[0].Bold)
+	assert.False(t, row[0].Reverse)
+	assert.True(t, row[6].Bold)
+	assert.Nil(t, row[6].Foreground)
+	assert.False(t, row[11].Bold)
+	assert.NotNil(t, row[11].Foreground)
+	assert.False(t, row[11].Reverse)
+	assert.True(t, row[15].Reverse)
+}
+
 func TestTerminal_Spawn(t *testing.T) {
 	gtk.Init(nil)
 
@@ -358,7 +554,8 @@ func TestTerminal_Spawn(t *testing.T) {
 		[]string{"/usr/bin/false"},
 		vte.CommandWithOnSpawn(func(pid int, err error) {
 			assert.NoError(t, err)
-			assert.Greater(t, pid, os.Getpid())
+			assert.Greater(t, pid, 0)
+			assert.NoError(t, syscall.Kill(pid, 0))
 			gtk.MainQuit()
 		}),
 	)
@@ -370,6 +567,102 @@ func TestTerminal_Spawn(t *testing.T) {
 	gtk.Main()
 }
 
+func TestTerminal_SpawnContext(t *testing.T) {
+	gtk.Init(nil)
+
+	pid, err := newTerm(t).SpawnContext(
+		context.Background(),
+		vte.CommandNew([]string{"/usr/bin/true"}),
+	)
+	assert.NoError(t, err)
+	assert.Greater(t, pid, 0)
+	assert.NoError(t, syscall.Kill(pid, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	pid, err = newTerm(t).SpawnContext(ctx, vte.CommandNew([]string{"/usr/bin/true"}))
+	assert.Equal(t, -1, pid)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTerminal_SpawnProcess(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+
+	p := term.Spawn(vte.CommandNew([]string{"/bin/sh", "-c", "kill -TERM $$"}))
+	waitProcess(p)
+
+	status, err := p.Wait()
+	assert.NoError(t, err)
+	assert.True(t, status.Signaled())
+	assert.Equal(t, syscall.SIGTERM, status.Signal())
+}
+
+func TestTerminal_SpawnProcessDestroyed(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+
+	var spawned bool
+
+	p := term.Spawn(vte.CommandNew(
+		[]string{"/bin/sleep", "1"},
+		vte.CommandWithOnSpawn(func(pid int, err error) {
+			assert.NoError(t, err)
+			spawned = true
+		}),
+	))
+
+	for !spawned {
+		glib.MainContextDefault().Iteration(true)
+	}
+
+	// The terminal no longer reports exit of the process, but Done must still
+	// be closed.
+	term.Destroy()
+	waitProcess(p)
+
+	_, err := p.Wait()
+	assert.NoError(t, err)
+}
+
+func TestTerminal_SpawnChildSetup(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+
+	cmd := vte.CommandNew(
+		[]string{"/bin/sh", "-c", "ulimit -n; umask"},
+		vte.CommandWithChildSetup(&vte.ChildSetup{
+			SetUmask: true,
+			Umask:    0o027,
+			Rlimits: []vte.Rlimit{
+				{Resource: syscall.RLIMIT_NOFILE, Cur: 123, Max: 123},
+			},
+			ResetSignalMask: true,
+		}),
+		vte.CommandWithOnSpawn(func(pid int, err error) {
+			assert.NoError(t, err)
+		}),
+	)
+
+	term.ConnectChildExited(func(_ *vte.Terminal, status int) {
+		assert.Equal(t, 0, status)
+		gtk.MainQuit()
+	})
+
+	term.Spawn(cmd)
+	gtk.Main()
+
+	text, err := term.GetText(vte.FORMAT_TEXT)
+	assert.NoError(t, err)
+	assert.Contains(t, text, "123\n")
+	assert.Contains(t, text, "0027\n")
+}
+
 func TestTerminal_SetColors(t *testing.T) {
 	term := newTerm(t)
 
@@ -432,6 +725,60 @@ func TestTerminal_SetColors(t *testing.T) {
 	))
 }
 
+func TestTerminal_SetColor(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+
+	term.SetColorForeground(gdk.NewRGBA(0.9, 0.9, 0.9, 1))
+	term.SetColorBackground(gdk.NewRGBA(0.2, 0.4, 0.6, 1))
+	term.SetColorBold(gdk.NewRGBA(1, 1, 1, 1))
+	term.SetColorBold(nil)
+
+	// Nil colors are ignored.
+	term.SetColorForeground(nil)
+	term.SetColorBackground(nil)
+
+	fg, bg := term.TrackedDefaultColors()
+	assert.Equal(t, vte.Color{R: 0.9, G: 0.9, B: 0.9, A: 1}, fg)
+	assert.Equal(t, vte.Color{R: 0.2, G: 0.4, B: 0.6, A: 1}, bg)
+
+	// Background color for drawing may only be queried while drawing.
+	win, err := gtk.WindowNew(gtk.WINDOW_TOPLEVEL)
+	assert.NoError(t, err)
+	defer win.Destroy()
+
+	var drawn *gdk.RGBA
+
+	term.Connect("draw", func() bool {
+		drawn = term.GetColorBackgroundForDraw()
+		return false
+	})
+
+	win.Add(term)
+	win.ShowAll()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for drawn == nil && time.Now().Before(deadline) {
+		gtk.MainIterationDo(false)
+	}
+
+	if assert.NotNil(t, drawn) {
+		assert.InDelta(t, 0.2, drawn.GetRed(), 0.01)
+		assert.InDelta(t, 0.4, drawn.GetGreen(), 0.01)
+		assert.InDelta(t, 0.6, drawn.GetBlue(), 0.01)
+		assert.InDelta(t, 1, drawn.GetAlpha(), 0.01)
+	}
+
+	term.SetDefaultColors()
+	fg, bg = term.TrackedDefaultColors()
+	assert.Equal(t, vte.Color{R: 0.75, G: 0.75, B: 0.75, A: 1}, fg)
+	assert.Equal(t, vte.Color{A: 1}, bg)
+
+	term.SetClearBackground(false)
+	term.SetClearBackground(true)
+}
+
 func TestTerminal_SearchRegex(t *testing.T) {
 	term := newTerm(t)
 
@@ -446,6 +793,12 @@ func TestTerminal_SearchRegex(t *testing.T) {
 	assert.NoError(t, err)
 	term.SearchSetRegex(invalid, 0)
 	assert.Equal(t, reg.Native(), term.SearchGetRegex().Native())
+
+	assert.Equal(t, "bar", term.SearchGetRegex().Pattern())
+	assert.Equal(t, vte.REGEX_PURPOSE_SEARCH, term.SearchGetRegex().Purpose())
+
+	term.SearchSetRegex(nil, 0)
+	assert.Equal(t, (*vte.Regex)(nil), term.SearchGetRegex())
 }
 
 func TestTerminal_Match(t *testing.T) {
@@ -483,6 +836,76 @@ func TestTerminal_Match(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTerminal_CheckMatchAt(t *testing.T) {
+	term := newTerm(t)
+
+	reg, err := vte.RegexNew(`https?://\S+`, vte.RegexWithPurpose(vte.REGEX_PURPOSE_MATCH))
+	assert.NoError(t, err)
+
+	h, err := term.MatchAddRegex(reg, 0)
+	assert.NoError(t, err)
+
+	feed(term, "see https://example.com for details")
+
+	text, handle, ok := term.CheckMatchAt(10, 0)
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com", text)
+	assert.Equal(t, h, handle)
+
+	text, handle, ok = term.CheckMatchAt(1, 0)
+	assert.False(t, ok)
+	assert.Equal(t, "", text)
+	assert.Equal(t, vte.MatchHandle(-1), handle)
+}
+
+func TestTerminal_CheckHyperlinkAt(t *testing.T) {
+	term := newTerm(t)
+	term.SetAllowHyperlink(true)
+
+	feed(term, "see \x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\ here")
+
+	uri, ok := term.CheckHyperlinkAt(5, 0)
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com", uri)
+
+	uri, ok = term.CheckHyperlinkAt(1, 0)
+	assert.False(t, ok)
+	assert.Equal(t, "", uri)
+}
+
+func TestTerminal_CheckRegexesAt(t *testing.T) {
+	term := newTerm(t)
+
+	word, err := vte.RegexNew(`\w+`, vte.RegexWithPurpose(vte.REGEX_PURPOSE_MATCH))
+	assert.NoError(t, err)
+
+	number, err := vte.RegexNew(`\d+`, vte.RegexWithPurpose(vte.REGEX_PURPOSE_MATCH))
+	assert.NoError(t, err)
+
+	path, err := vte.RegexNew(`/\S+`, vte.RegexWithPurpose(vte.REGEX_PURPOSE_MATCH))
+	assert.NoError(t, err)
+
+	feed(term, "issue 1234 fixed")
+
+	matches, err := term.CheckRegexesAt(7, 0, []*vte.Regex{word, number, path}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []vte.RegexMatch{
+		{Regex: word, Text: "1234"},
+		{Regex: number, Text: "1234"},
+	}, matches)
+
+	matches, err = term.CheckRegexesAt(7, 0, nil, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, matches)
+
+	search, err := vte.RegexNew(`\w+`)
+	assert.NoError(t, err)
+
+	matches, err = term.CheckRegexesAt(7, 0, []*vte.Regex{word, search}, 0)
+	assert.Error(t, err)
+	assert.Nil(t, matches)
+}
+
 func TestTerminal_SignalBell(t *testing.T) {
 	gtk.Init(nil)
 
@@ -535,6 +958,35 @@ func TestTerminal_SignalChildExited(t *testing.T) {
 	assert.IsIncreasing(t, callStack)
 }
 
+func TestTerminal_SignalChildExitedStatus(t *testing.T) {
+	gtk.Init(nil)
+
+	var callStack []int
+
+	cmd := vte.CommandNew([]string{"/bin/sh", "-c", "exit 42"})
+
+	term := newTerm(t)
+
+	term.ConnectChildExitedStatus(func(t *vte.Terminal, status vte.ExitStatus) {
+		callStack = append(callStack, 0)
+	})
+
+	term.ConnectAfterChildExitedStatus(func(_ *vte.Terminal, status vte.ExitStatus) {
+		callStack = append(callStack, 1)
+		assert.True(t, status.Exited())
+		assert.Equal(t, 42, status.ExitCode())
+		assert.False(t, status.Signaled())
+		gtk.MainQuit()
+	})
+
+	term.Spawn(cmd)
+
+	gtk.Main()
+
+	assert.Len(t, callStack, 2)
+	assert.IsIncreasing(t, callStack)
+}
+
 func TestTerminal_SignalContentsChanged(t *testing.T) {
 	gtk.Init(nil)
 
@@ -645,3 +1097,91 @@ func TestTerminal_SignalResizeWindow(t *testing.T) {
 	assert.Len(t, callStack, 2)
 	assert.IsIncreasing(t, callStack)
 }
+
+func TestTerminal_CurrentDirectory(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+
+	var changed *vte.Directory
+
+	handle := term.ConnectCurrentDirectoryChanged(func(_ *vte.Terminal, dir *vte.Directory) {
+		changed = dir
+		gtk.MainQuit()
+	})
+	term.Feed("\x1b]7;file://localhost/tmp\x1b\\")
+	gtk.Main()
+	term.HandlerDisconnect(handle)
+
+	assert.Equal(t, &vte.Directory{Path: "/tmp"}, changed)
+
+	dir, err := term.CurrentDirectory()
+	assert.NoError(t, err)
+	assert.Equal(t, &vte.Directory{Path: "/tmp"}, dir)
+}
+
+func TestTerminal_CurrentDirectoryFallback(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+
+	cwd := t.TempDir()
+
+	cmd := vte.CommandNew([]string{"/bin/sh", "-c", "sleep 5"}, vte.CommandWithWorkdir(cwd))
+	cmd.OnSpawn = func(pid int, err error) {
+		assert.NoError(t, err)
+		gtk.MainQuit()
+	}
+	term.Spawn(cmd)
+	gtk.Main()
+
+	dir, err := term.CurrentDirectory()
+	assert.NoError(t, err)
+	assert.Equal(t, &vte.Directory{Path: cwd}, dir)
+}
+
+func TestTerminal_ForegroundProcess(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+	dir := t.TempDir()
+
+	p := term.Spawn(vte.CommandNew(
+		[]string{"/bin/sh", "-c", "exec sleep 10"},
+		vte.CommandWithWorkdir(dir),
+		vte.CommandWithOnSpawn(func(pid int, err error) {
+			assert.NoError(t, err)
+			gtk.MainQuit()
+		}),
+	))
+	gtk.Main()
+
+	// Wait for the shell to exec.
+	assert.Eventually(t, func() bool {
+		info, err := term.ForegroundProcess()
+		return err == nil && info.Comm == "sleep"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	info, err := term.ForegroundProcess()
+	assert.NoError(t, err)
+	assert.Equal(t, p.Pid(), info.Pid)
+	assert.Equal(t, p.Pid(), info.Pgid)
+	assert.Equal(t, []string{"sleep", "10"}, info.Cmdline)
+	assert.Equal(t, dir, info.Cwd)
+
+	assert.NoError(t, term.SendSignalToForeground(syscall.SIGTERM))
+	waitProcess(p)
+
+	status, err := p.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, syscall.SIGTERM, status.Signal())
+}
+
+func TestTerminal_ForegroundProcessNoGroup(t *testing.T) {
+	term := newTerm(t)
+	term.SetPty(newPty(t))
+
+	_, err := term.ForegroundProcess()
+	assert.ErrorIs(t, err, vte.ErrNoForegroundProcess)
+	assert.ErrorIs(t, term.SendSignalToForeground(syscall.SIGTERM), vte.ErrNoForegroundProcess)
+}
@@ -0,0 +1,187 @@
+package vte
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// SpecialChar is an index of the special control character in the Cc field of
+// [syscall.Termios].
+type SpecialChar int
+
+const (
+	// Interrupt character (usually ^C), sends SIGINT.
+	SPECIAL_CHAR_INTR SpecialChar = syscall.VINTR
+
+	// Quit character (usually ^\), sends SIGQUIT.
+	SPECIAL_CHAR_QUIT SpecialChar = syscall.VQUIT
+
+	// Erase character (usually DEL or ^H), erases the previous character.
+	SPECIAL_CHAR_ERASE SpecialChar = syscall.VERASE
+
+	// Kill character (usually ^U), erases the current line.
+	SPECIAL_CHAR_KILL SpecialChar = syscall.VKILL
+
+	// End-of-file character (usually ^D).
+	SPECIAL_CHAR_EOF SpecialChar = syscall.VEOF
+
+	// Start character (usually ^Q), restarts output stopped by the stop
+	// character.
+	SPECIAL_CHAR_START SpecialChar = syscall.VSTART
+
+	// Stop character (usually ^S), stops output.
+	SPECIAL_CHAR_STOP SpecialChar = syscall.VSTOP
+
+	// Suspend character (usually ^Z), sends SIGTSTP.
+	SPECIAL_CHAR_SUSP SpecialChar = syscall.VSUSP
+
+	// Reprint character (usually ^R), reprints unread characters.
+	SPECIAL_CHAR_REPRINT SpecialChar = syscall.VREPRINT
+
+	// Word erase character (usually ^W), erases the previous word.
+	SPECIAL_CHAR_WERASE SpecialChar = syscall.VWERASE
+
+	// Literal next character (usually ^V), quotes the next input character.
+	SPECIAL_CHAR_LNEXT SpecialChar = syscall.VLNEXT
+
+	// Discard character (usually ^O), toggles discarding of pending output.
+	SPECIAL_CHAR_DISCARD SpecialChar = syscall.VDISCARD
+)
+
+// GetTermios returns terminal attributes of the pseudo terminal, like
+// tcgetattr(3).
+func (pty *Pty) GetTermios() (*syscall.Termios, error) {
+	var termios syscall.Termios
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		pty.GetFd(),
+		syscall.TCGETS,
+		uintptr(unsafe.Pointer(&termios)),
+	)
+	if errno != 0 {
+		return nil, os.NewSyscallError("tcgetattr", errno)
+	}
+
+	return &termios, nil
+}
+
+// SetTermios sets terminal attributes of the pseudo terminal immediately, like
+// tcsetattr(3) with TCSANOW.
+func (pty *Pty) SetTermios(termios *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		pty.GetFd(),
+		syscall.TCSETS,
+		uintptr(unsafe.Pointer(termios)),
+	)
+	if errno != 0 {
+		return os.NewSyscallError("tcsetattr", errno)
+	}
+
+	return nil
+}
+
+// updateTermios reads terminal attributes, modifies them with update and
+// writes them back.
+func (pty *Pty) updateTermios(update func(termios *syscall.Termios)) error {
+	termios, err := pty.GetTermios()
+	if err != nil {
+		return err
+	}
+
+	update(termios)
+	return pty.SetTermios(termios)
+}
+
+// SetRaw puts the pseudo terminal into raw mode, like cfmakeraw(3): input is
+// available character by character, echoing is disabled, and special
+// processing of input and output characters is disabled.
+func (pty *Pty) SetRaw() error {
+	return pty.updateTermios(func(termios *syscall.Termios) {
+		termios.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK |
+			syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL |
+			syscall.IXON
+		termios.Oflag &^= syscall.OPOST
+		termios.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON |
+			syscall.ISIG | syscall.IEXTEN
+		termios.Cflag &^= syscall.CSIZE | syscall.PARENB
+		termios.Cflag |= syscall.CS8
+		termios.Cc[syscall.VMIN] = 1
+		termios.Cc[syscall.VTIME] = 0
+	})
+}
+
+// GetEcho reports whether input characters are echoed by the pseudo terminal.
+//
+// Programs usually disable echoing while reading a password, so GetEcho can
+// be used to detect password prompts.
+func (pty *Pty) GetEcho() (bool, error) {
+	termios, err := pty.GetTermios()
+	if err != nil {
+		return false, err
+	}
+	return termios.Lflag&syscall.ECHO != 0, nil
+}
+
+// SetEcho sets whether input characters are echoed by the pseudo terminal.
+func (pty *Pty) SetEcho(v bool) error {
+	return pty.updateTermios(func(termios *syscall.Termios) {
+		if v {
+			termios.Lflag |= syscall.ECHO
+		} else {
+			termios.Lflag &^= syscall.ECHO
+		}
+	})
+}
+
+// SetIUTF8 sets whether input is UTF-8 encoded, so that the erase character
+// correctly erases multibyte characters in canonical mode.
+//
+// Unlike [Pty.SetUTF8], it only modifies terminal attributes and reports
+// errors of the underlying system call.
+func (pty *Pty) SetIUTF8(v bool) error {
+	return pty.updateTermios(func(termios *syscall.Termios) {
+		if v {
+			termios.Iflag |= syscall.IUTF8
+		} else {
+			termios.Iflag &^= syscall.IUTF8
+		}
+	})
+}
+
+// GetSpecialChar returns value of the special control character c. Value 0
+// means that the special character is disabled.
+func (pty *Pty) GetSpecialChar(c SpecialChar) (byte, error) {
+	if c < 0 || int(c) >= len(syscall.Termios{}.Cc) {
+		return 0, fmt.Errorf("vte: invalid special character %d", c)
+	}
+
+	termios, err := pty.GetTermios()
+	if err != nil {
+		return 0, err
+	}
+	return termios.Cc[c], nil
+}
+
+// SetSpecialChars sets values of the special control characters. Value 0
+// disables the special character.
+//
+// Setting [SPECIAL_CHAR_ERASE] to match the key sent by the terminal keeps
+// Backspace working when [Terminal.SetBackspaceBinding] is used with anything
+// other than [ERASE_TTY] or [ERASE_AUTO].
+func (pty *Pty) SetSpecialChars(chars map[SpecialChar]byte) error {
+	for c := range chars {
+		if c < 0 || int(c) >= len(syscall.Termios{}.Cc) {
+			return fmt.Errorf("vte: invalid special character %d", c)
+		}
+	}
+
+	return pty.updateTermios(func(termios *syscall.Termios) {
+		for c, v := range chars {
+			termios.Cc[c] = v
+		}
+	})
+}
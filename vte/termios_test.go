@@ -0,0 +1,85 @@
+package vte_test
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPty_Termios(t *testing.T) {
+	pty := newPty(t)
+
+	termios, err := pty.GetTermios()
+	assert.NoError(t, err)
+
+	termios.Lflag |= syscall.ICANON
+	assert.NoError(t, pty.SetTermios(termios))
+
+	termios, err = pty.GetTermios()
+	assert.NoError(t, err)
+	assert.NotZero(t, termios.Lflag&syscall.ICANON)
+}
+
+func TestPty_SetEcho(t *testing.T) {
+	pty := newPty(t)
+
+	assert.NoError(t, pty.SetEcho(false))
+	echo, err := pty.GetEcho()
+	assert.NoError(t, err)
+	assert.False(t, echo)
+
+	assert.NoError(t, pty.SetEcho(true))
+	echo, err = pty.GetEcho()
+	assert.NoError(t, err)
+	assert.True(t, echo)
+}
+
+func TestPty_SetRaw(t *testing.T) {
+	pty := newPty(t)
+
+	assert.NoError(t, pty.SetRaw())
+
+	termios, err := pty.GetTermios()
+	assert.NoError(t, err)
+	assert.Zero(t, termios.Lflag&(syscall.ICANON|syscall.ECHO|syscall.ISIG))
+	assert.Zero(t, termios.Oflag&syscall.OPOST)
+}
+
+func TestPty_SetIUTF8(t *testing.T) {
+	pty := newPty(t)
+
+	assert.NoError(t, pty.SetIUTF8(false))
+	termios, err := pty.GetTermios()
+	assert.NoError(t, err)
+	assert.Zero(t, termios.Iflag&syscall.IUTF8)
+
+	assert.NoError(t, pty.SetIUTF8(true))
+	termios, err = pty.GetTermios()
+	assert.NoError(t, err)
+	assert.NotZero(t, termios.Iflag&syscall.IUTF8)
+}
+
+func TestPty_SetSpecialChars(t *testing.T) {
+	pty := newPty(t)
+
+	err := pty.SetSpecialChars(map[vte.SpecialChar]byte{
+		vte.SPECIAL_CHAR_ERASE: 0x08,
+		vte.SPECIAL_CHAR_INTR:  0x03,
+	})
+	assert.NoError(t, err)
+
+	erase, err := pty.GetSpecialChar(vte.SPECIAL_CHAR_ERASE)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x08), erase)
+
+	intr, err := pty.GetSpecialChar(vte.SPECIAL_CHAR_INTR)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x03), intr)
+
+	assert.Error(t, pty.SetSpecialChars(map[vte.SpecialChar]byte{-1: 0}))
+
+	_, err = pty.GetSpecialChar(100)
+	assert.Error(t, err)
+}
@@ -0,0 +1,361 @@
+package vte
+
+// #cgo pkg-config: gtk+-3.0 vte-2.91
+//
+// #include <gtk/gtk.h>
+// #include <vte/vte.h>
+//
+// #include "glib.go.h"
+// #include "vte.go.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+)
+
+// ErrTermPropUnset is returned by termprop getters of [Terminal] if the
+// termprop is known, but has no value.
+var ErrTermPropUnset = errors.New("vte: termprop is unset")
+
+// TermPropInfo describes a termprop registered in VTE.
+type TermPropInfo struct {
+	// Name of the termprop. If the termprop was queried by the name of an
+	// alias, this is the name of the termprop the alias refers to.
+	Name TermProp
+
+	// Numeric ID of the termprop.
+	ID int
+
+	// Type of the termprop value.
+	Type PropertyType
+
+	// Flags of the termprop.
+	Flags PropertyFlags
+}
+
+// GetTermProps returns all termprops registered in VTE, including the
+// application-defined ones.
+func GetTermProps() []*TermPropInfo {
+	var length C.gsize
+
+	arr := C.vte_get_termprops(&length)
+	if arr == nil {
+		return nil
+	}
+	defer C.g_free(C.gpointer(unsafe.Pointer(arr)))
+
+	names := unsafe.Slice(arr, int(length))
+	props := make([]*TermPropInfo, 0, len(names))
+
+	for _, name := range names {
+		info, err := QueryTermProp(TermProp(goString(name)))
+		if err != nil {
+			continue
+		}
+		props = append(props, info)
+	}
+
+	return props
+}
+
+// QueryTermProp returns information about the termprop. If prop is the name of
+// an alias, the alias is resolved.
+func QueryTermProp(prop TermProp) (*TermPropInfo, error) {
+	cprop := C.CString(string(prop))
+	defer C.free(unsafe.Pointer(cprop))
+
+	var (
+		name  *C.char
+		id    C.int
+		typ   C.VtePropertyType
+		flags C.VtePropertyFlags
+	)
+
+	if !goBool(C.vte_query_termprop(cprop, &name, &id, &typ, &flags)) {
+		return nil, errCgoCall{
+			Function: "vte_query_termprop",
+			Detail:   fmt.Sprintf("unknown termprop %q", prop),
+		}
+	}
+
+	return &TermPropInfo{
+		Name:  TermProp(goString(name)),
+		ID:    int(id),
+		Type:  PropertyType(typ),
+		Flags: PropertyFlags(flags),
+	}, nil
+}
+
+// termPropCString checks that prop is one of the types and returns its name as
+// C string. The caller is responsible for freeing the string.
+func termPropCString(prop TermProp, types ...PropertyType) (*C.char, error) {
+	info, err := QueryTermProp(prop)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(types, info.Type) {
+		return nil, fmt.Errorf("vte: termprop %q has type %d", prop, info.Type)
+	}
+
+	return C.CString(string(prop)), nil
+}
+
+// IsTermPropSet reports whether the termprop has a value. This is the only way
+// to retrieve termprops of type [PROPERTY_VALUELESS].
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) IsTermPropSet(prop TermProp) (bool, error) {
+	if _, err := QueryTermProp(prop); err != nil {
+		return false, err
+	}
+
+	v, err := glib.ValueAlloc()
+	if err != nil {
+		return false, err
+	}
+
+	gvalue := C.toGValue(unsafe.Pointer(v.GValue))
+	cprop := C.CString(string(prop))
+	defer C.free(unsafe.Pointer(cprop))
+
+	return goBool(C.vte_terminal_get_termprop_value(t.native(), cprop, gvalue)), nil
+}
+
+// GetTermPropBool returns value of the termprop of type [PROPERTY_BOOL].
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropBool(prop TermProp) (bool, error) {
+	cprop, err := termPropCString(prop, PROPERTY_BOOL)
+	if err != nil {
+		return false, err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	var v C.gboolean
+	if !goBool(C.vte_terminal_get_termprop_bool(t.native(), cprop, &v)) {
+		return false, ErrTermPropUnset
+	}
+	return goBool(v), nil
+}
+
+// GetTermPropInt returns value of the termprop of type [PROPERTY_INT].
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropInt(prop TermProp) (int64, error) {
+	cprop, err := termPropCString(prop, PROPERTY_INT)
+	if err != nil {
+		return 0, err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	var v C.int64_t
+	if !goBool(C.vte_terminal_get_termprop_int(t.native(), cprop, &v)) {
+		return 0, ErrTermPropUnset
+	}
+	return int64(v), nil
+}
+
+// GetTermPropUint returns value of the termprop of type [PROPERTY_UINT].
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropUint(prop TermProp) (uint64, error) {
+	cprop, err := termPropCString(prop, PROPERTY_UINT)
+	if err != nil {
+		return 0, err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	var v C.uint64_t
+	if !goBool(C.vte_terminal_get_termprop_uint(t.native(), cprop, &v)) {
+		return 0, ErrTermPropUnset
+	}
+	return uint64(v), nil
+}
+
+// GetTermPropDouble returns value of the termprop of type [PROPERTY_DOUBLE].
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropDouble(prop TermProp) (float64, error) {
+	cprop, err := termPropCString(prop, PROPERTY_DOUBLE)
+	if err != nil {
+		return 0, err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	var v C.double
+	if !goBool(C.vte_terminal_get_termprop_double(t.native(), cprop, &v)) {
+		return 0, ErrTermPropUnset
+	}
+	return float64(v), nil
+}
+
+// GetTermPropString returns value of the termprop of type [PROPERTY_STRING].
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropString(prop TermProp) (string, error) {
+	cprop, err := termPropCString(prop, PROPERTY_STRING)
+	if err != nil {
+		return "", err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	var size C.size_t
+	cstr := C.vte_terminal_get_termprop_string(t.native(), cprop, &size)
+	if cstr == nil {
+		return "", ErrTermPropUnset
+	}
+	return C.GoStringN(cstr, C.int(size)), nil
+}
+
+// GetTermPropRGBA returns value of the termprop of type [PROPERTY_RGB] or
+// [PROPERTY_RGBA]. Alpha component of [PROPERTY_RGB] termprop is always 1.
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropRGBA(prop TermProp) (*gdk.RGBA, error) {
+	cprop, err := termPropCString(prop, PROPERTY_RGB, PROPERTY_RGBA)
+	if err != nil {
+		return nil, err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	var v C.GdkRGBA
+	if !goBool(C.vte_terminal_get_termprop_rgba(t.native(), cprop, &v)) {
+		return nil, ErrTermPropUnset
+	}
+	return gdk.NewRGBA(
+		float64(v.red),
+		float64(v.green),
+		float64(v.blue),
+		float64(v.alpha),
+	), nil
+}
+
+// GetTermPropUUID returns value of the termprop of type [PROPERTY_UUID] in the
+// simple form, e.g. "5d4b9c6e-7b8a-4a3e-9f1d-2c3b4a5d6e7f".
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropUUID(prop TermProp) (string, error) {
+	cprop, err := termPropCString(prop, PROPERTY_UUID)
+	if err != nil {
+		return "", err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	uuid := C.vte_terminal_dup_termprop_uuid(t.native(), cprop)
+	if uuid == nil {
+		return "", ErrTermPropUnset
+	}
+	defer C.vte_uuid_free(uuid)
+
+	cstr := C.vte_uuid_to_string(uuid, C.VTE_UUID_FORMAT_SIMPLE, nil)
+	if cstr == nil {
+		return "", errNilPointer("vte_uuid_to_string")
+	}
+	defer C.g_free(C.gpointer(unsafe.Pointer(cstr)))
+
+	return goString(cstr), nil
+}
+
+// GetTermPropURI returns value of the termprop of type [PROPERTY_URI].
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropURI(prop TermProp) (*url.URL, error) {
+	cprop, err := termPropCString(prop, PROPERTY_URI)
+	if err != nil {
+		return nil, err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	uri := C.vte_terminal_ref_termprop_uri(t.native(), cprop)
+	if uri == nil {
+		return nil, ErrTermPropUnset
+	}
+	defer C.g_uri_unref(uri)
+
+	cstr := C.g_uri_to_string(uri)
+	if cstr == nil {
+		return nil, errNilPointer("g_uri_to_string")
+	}
+	defer C.g_free(C.gpointer(unsafe.Pointer(cstr)))
+
+	return url.Parse(goString(cstr))
+}
+
+// GetTermPropData returns value of the termprop of type [PROPERTY_DATA].
+//
+// It should be called inside the callback of [Terminal.ConnectTermPropChanged]
+// or [Terminal.ConnectAfterTermPropChanged].
+func (t *Terminal) GetTermPropData(prop TermProp) ([]byte, error) {
+	cprop, err := termPropCString(prop, PROPERTY_DATA)
+	if err != nil {
+		return nil, err
+	}
+	defer C.free(unsafe.Pointer(cprop))
+
+	var size C.size_t
+	data := C.vte_terminal_get_termprop_data(t.native(), cprop, &size)
+	if data == nil {
+		return nil, ErrTermPropUnset
+	}
+	return C.GoBytes(unsafe.Pointer(data), C.int(size)), nil
+}
+
+// InstallTermProp installs an application-defined termprop. It returns name of
+// the installed termprop, which is also passed to the callbacks of
+// [Terminal.ConnectTermPropChanged].
+//
+// Name of the termprop must start with "vte.ext." for it to be settable by the
+// OSC 666 escape sequence, e.g. "\x1b]666;vte.ext.example=value\x1b\\".
+//
+// Termprops must be installed before the first [Terminal] is created.
+// Installing a termprop with the name of existing termprop succeeds only if
+// type and flags are the same.
+func InstallTermProp(name string, typ PropertyType, flags PropertyFlags) (TermProp, error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	id := C.vte_install_termprop(
+		cname,
+		C.VtePropertyType(typ),
+		C.VtePropertyFlags(flags),
+	)
+	if id < 0 {
+		return "", errFailed("vte_install_termprop")
+	}
+
+	return TermProp(name), nil
+}
+
+// InstallTermPropAlias installs an alias of the termprop target. Getters of
+// [Terminal] and [QueryTermProp] accept both the alias and the target name.
+//
+// Aliases must be installed before the first [Terminal] is created.
+func InstallTermPropAlias(name string, target TermProp) (TermProp, error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	ctarget := C.CString(string(target))
+	defer C.free(unsafe.Pointer(ctarget))
+
+	if C.vte_install_termprop_alias(cname, ctarget) < 0 {
+		return "", errFailed("vte_install_termprop_alias")
+	}
+
+	return TermProp(name), nil
+}
@@ -0,0 +1,133 @@
+package vte_test
+
+import (
+	"testing"
+
+	"github.com/gotk3/gotk3/gtk"
+	"github.com/shelepuginivan/gotk3-vte/vte"
+	"github.com/stretchr/testify/assert"
+)
+
+// Application-defined termprops must be installed before the first terminal is
+// created.
+var (
+	testTermProp, testTermPropErr = vte.InstallTermProp(
+		"vte.ext.gotk3.test",
+		vte.PROPERTY_STRING,
+		vte.PROPERTY_FLAG_NONE,
+	)
+	testTermPropAlias, testTermPropAliasErr = vte.InstallTermPropAlias(
+		"vte.ext.gotk3.alias",
+		testTermProp,
+	)
+)
+
+func TestInstallTermProp(t *testing.T) {
+	gtk.Init(nil)
+
+	assert.NoError(t, testTermPropErr)
+	assert.NoError(t, testTermPropAliasErr)
+	assert.Equal(t, vte.TermProp("vte.ext.gotk3.test"), testTermProp)
+
+	info, err := vte.QueryTermProp(testTermPropAlias)
+	assert.NoError(t, err)
+	assert.Equal(t, testTermProp, info.Name)
+	assert.Equal(t, vte.PROPERTY_STRING, info.Type)
+
+	// Installing the same termprop with the same type is allowed.
+	_, err = vte.InstallTermProp("vte.ext.gotk3.test", vte.PROPERTY_STRING, vte.PROPERTY_FLAG_NONE)
+	assert.NoError(t, err)
+
+	term := newTerm(t)
+
+	var changed []vte.TermProp
+
+	handle := term.ConnectTermPropChanged(func(t *vte.Terminal, prop vte.TermProp) {
+		changed = append(changed, prop)
+		gtk.MainQuit()
+	})
+	term.Feed("\x1b]666;vte.ext.gotk3.test=passing\x1b\\")
+	gtk.Main()
+	term.HandlerDisconnect(handle)
+
+	assert.Contains(t, changed, testTermProp)
+
+	value, err := term.GetTermPropString(testTermProp)
+	assert.NoError(t, err)
+	assert.Equal(t, "passing", value)
+
+	value, err = term.GetTermPropString(testTermPropAlias)
+	assert.NoError(t, err)
+	assert.Equal(t, "passing", value)
+}
+
+func TestQueryTermProp(t *testing.T) {
+	info, err := vte.QueryTermProp(vte.TERMPROP_XTERM_TITLE)
+	assert.NoError(t, err)
+	assert.Equal(t, vte.TERMPROP_XTERM_TITLE, info.Name)
+	assert.Equal(t, vte.PROPERTY_STRING, info.Type)
+
+	info, err = vte.QueryTermProp(vte.TERMPROP_CURRENT_DIRECTORY_URI)
+	assert.NoError(t, err)
+	assert.Equal(t, vte.PROPERTY_URI, info.Type)
+
+	info, err = vte.QueryTermProp(vte.TERMPROP_SHELL_PRECMD)
+	assert.NoError(t, err)
+	assert.Equal(t, vte.PROPERTY_VALUELESS, info.Type)
+
+	info, err = vte.QueryTermProp("vte.ext.unknown")
+	assert.Nil(t, info)
+	assert.Error(t, err)
+}
+
+func TestGetTermProps(t *testing.T) {
+	props := vte.GetTermProps()
+	assert.NotEmpty(t, props)
+
+	names := make([]vte.TermProp, len(props))
+	for i, info := range props {
+		names[i] = info.Name
+	}
+
+	assert.Contains(t, names, vte.TERMPROP_XTERM_TITLE)
+	assert.Contains(t, names, vte.TERMPROP_CURRENT_DIRECTORY_URI)
+}
+
+func TestTerminal_GetTermPropTyped(t *testing.T) {
+	gtk.Init(nil)
+
+	term := newTerm(t)
+
+	title, err := term.GetTermPropString(vte.TERMPROP_XTERM_TITLE)
+	assert.Equal(t, "", title)
+	assert.ErrorIs(t, err, vte.ErrTermPropUnset)
+
+	handle := term.ConnectTermPropChanged(func(t *vte.Terminal, prop vte.TermProp) {
+		gtk.MainQuit()
+	})
+	term.Feed("\x1b]0;something\x07")
+	gtk.Main()
+	term.HandlerDisconnect(handle)
+
+	title, err = term.GetTermPropString(vte.TERMPROP_XTERM_TITLE)
+	assert.NoError(t, err)
+	assert.Equal(t, "something", title)
+
+	value, err := term.GetTermProp(vte.TERMPROP_XTERM_TITLE)
+	assert.NoError(t, err)
+	assert.Equal(t, "something", value)
+
+	_, err = term.GetTermPropURI(vte.TERMPROP_XTERM_TITLE)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, vte.ErrTermPropUnset)
+
+	_, err = term.GetTermPropURI(vte.TERMPROP_CURRENT_DIRECTORY_URI)
+	assert.ErrorIs(t, err, vte.ErrTermPropUnset)
+
+	set, err := term.IsTermPropSet(vte.TERMPROP_SHELL_PRECMD)
+	assert.NoError(t, err)
+	assert.False(t, set)
+
+	_, err = term.IsTermPropSet("vte.ext.unknown")
+	assert.Error(t, err)
+}
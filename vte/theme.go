@@ -0,0 +1,44 @@
+package vte
+
+import (
+	"image/color"
+
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+)
+
+// ApplyTheme sets colors of the terminal from the color scheme th. Colors that
+// th does not specify are reset to defaults.
+func (t *Terminal) ApplyTheme(th *theme.Theme) error {
+	if err := th.Validate(); err != nil {
+		return err
+	}
+
+	palette := make([]*gdk.RGBA, len(th.Palette))
+	for i, c := range th.Palette {
+		palette[i] = gdkRGBA(c)
+	}
+
+	err := t.SetColors(gdkRGBA(th.Background), gdkRGBA(th.Foreground), palette)
+	if err != nil {
+		return err
+	}
+
+	t.SetCursorColor(gdkRGBAOrNil(th.Cursor), gdkRGBAOrNil(th.CursorText))
+	t.SetHighlightColor(gdkRGBAOrNil(th.Highlight), gdkRGBAOrNil(th.HighlightText))
+	t.SetColorBold(gdkRGBAOrNil(th.Bold))
+	return nil
+}
+
+// gdkRGBA converts c to [gdk.RGBA].
+func gdkRGBA(c color.NRGBA) *gdk.RGBA {
+	return ColorFrom(c).Gdk()
+}
+
+// gdkRGBAOrNil converts c to [gdk.RGBA], or returns nil if c is nil.
+func gdkRGBAOrNil(c *color.NRGBA) *gdk.RGBA {
+	if c == nil {
+		return nil
+	}
+	return gdkRGBA(*c)
+}
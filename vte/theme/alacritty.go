@@ -0,0 +1,164 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// alacrittyConfig is the subset of Alacritty configuration that defines
+// colors.
+type alacrittyConfig struct {
+	Colors struct {
+		Primary struct {
+			Foreground string `toml:"foreground" yaml:"foreground"`
+			Background string `toml:"background" yaml:"background"`
+		} `toml:"primary" yaml:"primary"`
+
+		Cursor struct {
+			Text   string `toml:"text" yaml:"text"`
+			Cursor string `toml:"cursor" yaml:"cursor"`
+		} `toml:"cursor" yaml:"cursor"`
+
+		Selection struct {
+			Text       string `toml:"text" yaml:"text"`
+			Background string `toml:"background" yaml:"background"`
+		} `toml:"selection" yaml:"selection"`
+
+		Normal alacrittyColors `toml:"normal" yaml:"normal"`
+		Bright alacrittyColors `toml:"bright" yaml:"bright"`
+
+		Indexed []struct {
+			Index int    `toml:"index" yaml:"index"`
+			Color string `toml:"color" yaml:"color"`
+		} `toml:"indexed_colors" yaml:"indexed_colors"`
+	} `toml:"colors" yaml:"colors"`
+}
+
+type alacrittyColors struct {
+	Black   string `toml:"black" yaml:"black"`
+	Red     string `toml:"red" yaml:"red"`
+	Green   string `toml:"green" yaml:"green"`
+	Yellow  string `toml:"yellow" yaml:"yellow"`
+	Blue    string `toml:"blue" yaml:"blue"`
+	Magenta string `toml:"magenta" yaml:"magenta"`
+	Cyan    string `toml:"cyan" yaml:"cyan"`
+	White   string `toml:"white" yaml:"white"`
+}
+
+func (c *alacrittyColors) list() []string {
+	return []string{c.Black, c.Red, c.Green, c.Yellow, c.Blue, c.Magenta, c.Cyan, c.White}
+}
+
+// ParseAlacrittyTOML parses colors section of Alacritty configuration in TOML
+// format, used by Alacritty 0.13 and later.
+func ParseAlacrittyTOML(r io.Reader) (*Theme, error) {
+	var config alacrittyConfig
+
+	if _, err := toml.NewDecoder(r).Decode(&config); err != nil {
+		return nil, err
+	}
+
+	return config.theme()
+}
+
+// ParseAlacrittyYAML parses colors section of Alacritty configuration in YAML
+// format, used by Alacritty prior to 0.13.
+func ParseAlacrittyYAML(r io.Reader) (*Theme, error) {
+	var config alacrittyConfig
+
+	if err := yaml.NewDecoder(r).Decode(&config); err != nil {
+		return nil, err
+	}
+
+	return config.theme()
+}
+
+func (config *alacrittyConfig) theme() (*Theme, error) {
+	var (
+		theme  Theme
+		colors = config.Colors
+		err    error
+	)
+
+	if colors.Primary.Foreground == "" || colors.Primary.Background == "" {
+		return nil, errors.New("Alacritty configuration must define primary foreground and background colors")
+	}
+
+	if theme.Foreground, err = ParseColor(colors.Primary.Foreground); err != nil {
+		return nil, fmt.Errorf("colors.primary.foreground: %w", err)
+	}
+	if theme.Background, err = ParseColor(colors.Primary.Background); err != nil {
+		return nil, fmt.Errorf("colors.primary.background: %w", err)
+	}
+
+	optional := []struct {
+		name   string
+		value  string
+		target **color.NRGBA
+	}{
+		{"colors.cursor.cursor", colors.Cursor.Cursor, &theme.Cursor},
+		{"colors.cursor.text", colors.Cursor.Text, &theme.CursorText},
+		{"colors.selection.background", colors.Selection.Background, &theme.Highlight},
+		{"colors.selection.text", colors.Selection.Text, &theme.HighlightText},
+	}
+
+	for _, o := range optional {
+		c, ok, err := alacrittyColor(o.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", o.name, err)
+		}
+		if ok {
+			*o.target = &c
+		}
+	}
+
+	p := make(palette)
+
+	for i, value := range append(colors.Normal.list(), colors.Bright.list()...) {
+		if value == "" {
+			continue
+		}
+
+		c, err := ParseColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("color %d: %w", i, err)
+		}
+		p[i] = c
+	}
+
+	for _, indexed := range colors.Indexed {
+		if indexed.Index < 16 || indexed.Index > 255 {
+			return nil, fmt.Errorf("colors.indexed_colors: index %d is out of range 16-255", indexed.Index)
+		}
+
+		c, err := ParseColor(indexed.Color)
+		if err != nil {
+			return nil, fmt.Errorf("color %d: %w", indexed.Index, err)
+		}
+		p[indexed.Index] = c
+	}
+
+	theme.Palette = p.build()
+	return &theme, nil
+}
+
+// alacrittyColor parses optional color. Alacritty allows special values
+// "CellForeground" and "CellBackground", which have no equivalent in VTE and
+// are treated as unset.
+func alacrittyColor(s string) (color.NRGBA, bool, error) {
+	if s == "" || strings.HasPrefix(s, "Cell") {
+		return color.NRGBA{}, false, nil
+	}
+
+	c, err := ParseColor(s)
+	if err != nil {
+		return color.NRGBA{}, false, err
+	}
+	return c, true, nil
+}
@@ -0,0 +1,66 @@
+package theme_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAlacrittyTOML(t *testing.T) {
+	f, err := os.Open("testdata/tomorrow.toml")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	th, err := theme.ParseAlacrittyTOML(f)
+	assert.NoError(t, err)
+
+	assert.Equal(t, rgb(0xff, 0xff, 0xff), *th.Cursor)
+	assert.Equal(t, rgb(0x37, 0x3b, 0x41), *th.Highlight)
+
+	// Cell colors have no equivalent and are left unset.
+	assert.Nil(t, th.CursorText)
+	assert.Nil(t, th.HighlightText)
+
+	assert.Len(t, th.Palette, 256)
+	assert.Equal(t, rgb(0xcc, 0x66, 0x66), th.Palette[1])
+	assert.Equal(t, rgb(0xea, 0xea, 0xea), th.Palette[15])
+	assert.Equal(t, rgb(0xff, 0x87, 0x00), th.Palette[16])
+	assert.Equal(t, rgb(0x00, 0x00, 0x5f), th.Palette[17])
+
+	t.Run("Invalid configuration", func(t *testing.T) {
+		for _, s := range []string{
+			"colors = 1",
+			"[colors.primary]\nforeground = \"#fff\"",
+			"[colors.primary]\nforeground = \"#fff\"\nbackground = \"#000\"\n[colors.normal]\nred = \"red\"",
+			"[colors.primary]\nforeground = \"#fff\"\nbackground = \"#000\"\n[[colors.indexed_colors]]\nindex = 3\ncolor = \"#fff\"",
+		} {
+			_, err := theme.ParseAlacrittyTOML(strings.NewReader(s))
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestParseAlacrittyYAML(t *testing.T) {
+	f, err := os.Open("testdata/tomorrow.yml")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	th, err := theme.ParseAlacrittyYAML(f)
+	assert.NoError(t, err)
+
+	assert.Equal(t, rgb(0xc5, 0xc8, 0xc6), th.Foreground)
+	assert.Equal(t, rgb(0xff, 0xff, 0xff), *th.Cursor)
+	assert.Nil(t, th.Highlight)
+
+	assert.Len(t, th.Palette, 16)
+	assert.Equal(t, rgb(0xb5, 0xbd, 0x68), th.Palette[2])
+
+	// Bright colors are not defined.
+	assert.Equal(t, rgb(0xff, 0x00, 0x00), th.Palette[9])
+
+	_, err = theme.ParseAlacrittyYAML(strings.NewReader("colors: []"))
+	assert.Error(t, err)
+}
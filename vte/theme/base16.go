@@ -0,0 +1,113 @@
+package theme
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// base16Scheme is a base16 color scheme. Both the original format, where
+// colors are top-level keys, and the tinted-theming format, where colors are
+// nested under the "palette" key, are supported.
+type base16Scheme struct {
+	Scheme  string            `yaml:"scheme"`
+	Name    string            `yaml:"name"`
+	Palette map[string]string `yaml:"palette"`
+	Colors  map[string]string `yaml:",inline"`
+}
+
+// base16Palette maps the 16 colors of the terminal palette to base16 colors,
+// as done by base16-shell.
+var base16Palette = [16]string{
+	"base00", "base08", "base0B", "base0A", "base0D", "base0E", "base0C", "base05",
+	"base03", "base08", "base0B", "base0A", "base0D", "base0E", "base0C", "base07",
+}
+
+// ParseBase16 parses base16 color scheme in YAML format.
+//
+// Terminal colors are derived from base16 colors as done by base16-shell:
+// background is base00, foreground and cursor are base05, and selection is
+// base02.
+func ParseBase16(r io.Reader) (*Theme, error) {
+	var scheme base16Scheme
+
+	if err := yaml.NewDecoder(r).Decode(&scheme); err != nil {
+		return nil, err
+	}
+
+	colors := scheme.Palette
+	if colors == nil {
+		colors = scheme.Colors
+	}
+
+	base := make(map[string]color.NRGBA, 16)
+
+	for i := range 16 {
+		key := fmt.Sprintf("base%02X", i)
+
+		// Some schemes use lowercase hexadecimal digits in color names.
+		value, ok := colors[key]
+		if !ok {
+			value, ok = colors[strings.ToLower(key)]
+		}
+		if !ok {
+			return nil, fmt.Errorf("base16 scheme has no %s color", key)
+		}
+
+		if !strings.HasPrefix(value, "#") {
+			value = "#" + value
+		}
+
+		c, err := ParseColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		base[key] = c
+	}
+
+	var (
+		cursor    = base["base05"]
+		highlight = base["base02"]
+		theme     = Theme{
+			Name:       scheme.Name,
+			Foreground: base["base05"],
+			Background: base["base00"],
+			Cursor:     &cursor,
+			Highlight:  &highlight,
+			Palette:    make([]color.NRGBA, 16),
+		}
+	)
+
+	if theme.Name == "" {
+		theme.Name = scheme.Scheme
+	}
+
+	for i, key := range base16Palette {
+		theme.Palette[i] = base[key]
+	}
+
+	return &theme, nil
+}
+
+// isBase16 reports whether YAML document looks like a base16 scheme rather than
+// Alacritty configuration.
+func isBase16(data []byte) bool {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+
+	if _, ok := doc["colors"]; ok {
+		return false
+	}
+
+	if palette, ok := doc["palette"].(map[string]any); ok {
+		doc = palette
+	}
+
+	_, ok := doc["base00"]
+	return ok
+}
@@ -0,0 +1,46 @@
+package theme_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBase16(t *testing.T) {
+	for _, file := range []string{"base16-tomorrow-night.yaml", "tinted-tomorrow.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			f, err := os.Open("testdata/" + file)
+			assert.NoError(t, err)
+			defer f.Close()
+
+			th, err := theme.ParseBase16(f)
+			assert.NoError(t, err)
+
+			assert.Equal(t, rgb(0xc5, 0xc8, 0xc6), th.Foreground)
+			assert.Equal(t, rgb(0x1d, 0x1f, 0x21), th.Background)
+			assert.Equal(t, rgb(0xc5, 0xc8, 0xc6), *th.Cursor)
+			assert.Equal(t, rgb(0x37, 0x3b, 0x41), *th.Highlight)
+
+			assert.Len(t, th.Palette, 16)
+			assert.Equal(t, rgb(0x1d, 0x1f, 0x21), th.Palette[0])
+			assert.Equal(t, rgb(0xcc, 0x66, 0x66), th.Palette[1])
+			assert.Equal(t, rgb(0xf0, 0xc6, 0x74), th.Palette[3])
+			assert.Equal(t, rgb(0x96, 0x98, 0x96), th.Palette[8])
+			assert.Equal(t, rgb(0xcc, 0x66, 0x66), th.Palette[9])
+			assert.Equal(t, rgb(0xff, 0xff, 0xff), th.Palette[15])
+		})
+	}
+
+	t.Run("Invalid scheme", func(t *testing.T) {
+		for _, s := range []string{
+			"[]",
+			`scheme: "Incomplete"` + "\nbase00: \"000000\"",
+		} {
+			_, err := theme.ParseBase16(strings.NewReader(s))
+			assert.Error(t, err, s)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package theme
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// ParseColor parses color specification used by terminal color schemes. The
+// following formats are supported:
+//
+//   - #rgb, #rrggbb, #rrrgggbbb, and #rrrrggggbbbb;
+//   - 0xrrggbb;
+//   - X11 rgb:r/g/b, where each component consists of 1 to 4 hexadecimal
+//     digits.
+func ParseColor(s string) (color.NRGBA, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case strings.HasPrefix(s, "#"):
+		return parseHexColor(s[1:], s)
+	case strings.HasPrefix(s, "0x"), strings.HasPrefix(s, "0X"):
+		if len(s) != 8 {
+			break
+		}
+		return parseHexColor(s[2:], s)
+	case strings.HasPrefix(s, "rgb:"):
+		parts := strings.Split(s[4:], "/")
+		if len(parts) != 3 {
+			break
+		}
+
+		var rgb [3]uint8
+		for i, part := range parts {
+			v, ok := parseHexComponent(part)
+			if !ok {
+				return color.NRGBA{}, fmt.Errorf("theme: invalid color %q", s)
+			}
+			rgb[i] = v
+		}
+
+		return color.NRGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 0xff}, nil
+	}
+
+	return color.NRGBA{}, fmt.Errorf("theme: invalid color %q", s)
+}
+
+// parseHexColor parses hex digits of the color, where each component consists
+// of the same number of digits.
+func parseHexColor(digits, s string) (color.NRGBA, error) {
+	if len(digits) == 0 || len(digits)%3 != 0 || len(digits) > 12 {
+		return color.NRGBA{}, fmt.Errorf("theme: invalid color %q", s)
+	}
+
+	n := len(digits) / 3
+
+	var rgb [3]uint8
+	for i := range rgb {
+		v, ok := parseHexComponent(digits[i*n : (i+1)*n])
+		if !ok {
+			return color.NRGBA{}, fmt.Errorf("theme: invalid color %q", s)
+		}
+		rgb[i] = v
+	}
+
+	return color.NRGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 0xff}, nil
+}
+
+// parseHexComponent parses color component of 1 to 4 hexadecimal digits and
+// scales it to 8 bits.
+func parseHexComponent(s string) (uint8, bool) {
+	if len(s) == 0 || len(s) > 4 {
+		return 0, false
+	}
+
+	v, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, false
+	}
+
+	limit := uint64(1)<<(4*len(s)) - 1
+	return uint8((v*0xff + limit/2) / limit), true
+}
+
+// rgbFloat returns color from components in range [0, 1].
+func rgbFloat(r, g, b, a float64) color.NRGBA {
+	return color.NRGBA{
+		R: floatComponent(r),
+		G: floatComponent(g),
+		B: floatComponent(b),
+		A: floatComponent(a),
+	}
+}
+
+func floatComponent(v float64) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 1:
+		return 0xff
+	}
+	return uint8(v*0xff + 0.5)
+}
+
+// xtermBase contains the first 16 colors of the xterm palette.
+var xtermBase = [16]color.NRGBA{
+	{0x00, 0x00, 0x00, 0xff},
+	{0xcd, 0x00, 0x00, 0xff},
+	{0x00, 0xcd, 0x00, 0xff},
+	{0xcd, 0xcd, 0x00, 0xff},
+	{0x00, 0x00, 0xee, 0xff},
+	{0xcd, 0x00, 0xcd, 0xff},
+	{0x00, 0xcd, 0xcd, 0xff},
+	{0xe5, 0xe5, 0xe5, 0xff},
+	{0x7f, 0x7f, 0x7f, 0xff},
+	{0xff, 0x00, 0x00, 0xff},
+	{0x00, 0xff, 0x00, 0xff},
+	{0xff, 0xff, 0x00, 0xff},
+	{0x5c, 0x5c, 0xff, 0xff},
+	{0xff, 0x00, 0xff, 0xff},
+	{0x00, 0xff, 0xff, 0xff},
+	{0xff, 0xff, 0xff, 0xff},
+}
+
+// xtermColor returns i-th color of the xterm 256-color palette.
+func xtermColor(i int) color.NRGBA {
+	switch {
+	case i < 16:
+		return xtermBase[i]
+	case i < 232:
+		levels := [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+		i -= 16
+		return color.NRGBA{levels[i/36], levels[i/6%6], levels[i%6], 0xff}
+	default:
+		v := uint8(8 + 10*(i-232))
+		return color.NRGBA{v, v, v, 0xff}
+	}
+}
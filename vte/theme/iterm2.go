@@ -0,0 +1,232 @@
+package theme
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseITerm2 parses iTerm2 color preset (.itermcolors file), which is an XML
+// property list.
+func ParseITerm2(r io.Reader) (*Theme, error) {
+	dec := xml.NewDecoder(r)
+
+	root, err := decodePlist(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	dict, ok := root.(map[string]any)
+	if !ok {
+		return nil, errors.New("iTerm2 preset must be a dictionary")
+	}
+
+	var (
+		theme  Theme
+		colors = make(palette)
+		hasFg  bool
+		hasBg  bool
+	)
+
+	for key, value := range dict {
+		c, ok, err := iterm2Color(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "Foreground Color":
+			theme.Foreground = c
+			hasFg = true
+		case "Background Color":
+			theme.Background = c
+			hasBg = true
+		case "Cursor Color":
+			theme.Cursor = &c
+		case "Cursor Text Color":
+			theme.CursorText = &c
+		case "Selection Color":
+			theme.Highlight = &c
+		case "Selected Text Color":
+			theme.HighlightText = &c
+		case "Bold Color":
+			theme.Bold = &c
+		default:
+			var i int
+			if _, err := fmt.Sscanf(key, "Ansi %d Color", &i); err == nil && i >= 0 && i < 16 {
+				colors[i] = c
+			}
+		}
+	}
+
+	if !hasFg || !hasBg {
+		return nil, errors.New("iTerm2 preset must define foreground and background colors")
+	}
+
+	theme.Palette = colors.build()
+	return &theme, nil
+}
+
+// iterm2Color converts dictionary of color components to color. The second
+// return value is false if value is not a color dictionary.
+func iterm2Color(value any) (color.NRGBA, bool, error) {
+	dict, ok := value.(map[string]any)
+	if !ok {
+		return color.NRGBA{}, false, nil
+	}
+
+	component := func(key string, def float64) (float64, error) {
+		v, ok := dict[key]
+		if !ok {
+			return def, nil
+		}
+		f, ok := v.(float64)
+		if !ok {
+			return 0, fmt.Errorf("%s must be a number", key)
+		}
+		return f, nil
+	}
+
+	var rgba [4]float64
+	for i, key := range []string{"Red Component", "Green Component", "Blue Component"} {
+		if _, ok := dict[key]; !ok {
+			return color.NRGBA{}, false, fmt.Errorf("color has no %s", key)
+		}
+		v, err := component(key, 0)
+		if err != nil {
+			return color.NRGBA{}, false, err
+		}
+		rgba[i] = v
+	}
+
+	alpha, err := component("Alpha Component", 1)
+	if err != nil {
+		return color.NRGBA{}, false, err
+	}
+	rgba[3] = alpha
+
+	return rgbFloat(rgba[0], rgba[1], rgba[2], rgba[3]), true, nil
+}
+
+// decodePlist decodes the top-level value of the XML property list. Values
+// are decoded to map[string]any, []any, string, float64 (both integers and
+// reals), and bool. Dates and data are decoded as strings.
+func decodePlist(dec *xml.Decoder) (any, error) {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("property list is empty")
+			}
+			return nil, err
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if start.Name.Local == "plist" {
+			continue
+		}
+
+		return decodePlistValue(dec, start)
+	}
+}
+
+func decodePlistValue(dec *xml.Decoder, start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict":
+		dict := make(map[string]any)
+		key := ""
+		hasKey := false
+
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			switch tok := tok.(type) {
+			case xml.StartElement:
+				if tok.Name.Local == "key" {
+					if err := dec.DecodeElement(&key, &tok); err != nil {
+						return nil, err
+					}
+					hasKey = true
+					continue
+				}
+
+				if !hasKey {
+					return nil, errors.New("property list dictionary value has no key")
+				}
+
+				value, err := decodePlistValue(dec, tok)
+				if err != nil {
+					return nil, err
+				}
+				dict[key] = value
+				hasKey = false
+
+			case xml.EndElement:
+				return dict, nil
+			}
+		}
+
+	case "array":
+		var arr []any
+
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			switch tok := tok.(type) {
+			case xml.StartElement:
+				value, err := decodePlistValue(dec, tok)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, value)
+
+			case xml.EndElement:
+				return arr, nil
+			}
+		}
+
+	case "true", "false":
+		if err := dec.Skip(); err != nil {
+			return nil, err
+		}
+		return start.Name.Local == "true", nil
+
+	case "real", "integer":
+		var s string
+		if err := dec.DecodeElement(&s, &start); err != nil {
+			return nil, err
+		}
+
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid property list number %q", s)
+		}
+		return f, nil
+
+	case "string", "date", "data":
+		var s string
+		if err := dec.DecodeElement(&s, &start); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	return nil, fmt.Errorf("unsupported property list element <%s>", start.Name.Local)
+}
@@ -0,0 +1,45 @@
+package theme_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseITerm2(t *testing.T) {
+	f, err := os.Open("testdata/tomorrow.itermcolors")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	th, err := theme.ParseITerm2(f)
+	assert.NoError(t, err)
+
+	assert.Equal(t, rgb(0x1d, 0x1f, 0x21), th.Background)
+	assert.Equal(t, rgb(0xff, 0xff, 0xff), *th.Cursor)
+	assert.Nil(t, th.CursorText)
+	assert.Equal(t, uint8(0x80), th.Highlight.A)
+	assert.Nil(t, th.Bold)
+
+	assert.Len(t, th.Palette, 16)
+	assert.Equal(t, rgb(0x00, 0x00, 0x00), th.Palette[0])
+	assert.Equal(t, rgb(0xff, 0x00, 0x00), th.Palette[1])
+
+	// Colors missing from the preset are xterm defaults.
+	assert.Equal(t, rgb(0xff, 0xff, 0xff), th.Palette[15])
+
+	t.Run("Invalid preset", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"<plist><array></array></plist>",
+			"<plist><dict><key>Foreground Color</key><dict></dict></dict></plist>",
+			"<plist><dict><string>value without key</string></dict></plist>",
+			"<plist><dict></dict></plist>",
+		} {
+			_, err := theme.ParseITerm2(strings.NewReader(s))
+			assert.Error(t, err, s)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+// Package theme provides terminal color schemes and parsers for scheme formats
+// of other terminal emulators.
+//
+// Parsed [Theme] can be applied to the terminal with
+// [github.com/shelepuginivan/gotk3-vte/vte.Terminal.ApplyTheme].
+package theme
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Theme represents a terminal color scheme.
+type Theme struct {
+	// Name of the theme, or an empty string ("") if the scheme format does not
+	// specify it.
+	Name string
+
+	// Default foreground color.
+	Foreground color.NRGBA
+
+	// Default background color.
+	Background color.NRGBA
+
+	// Color of the cursor, or nil to use the default.
+	Cursor *color.NRGBA
+
+	// Color of the text under the cursor, or nil to use the default.
+	CursorText *color.NRGBA
+
+	// Background color of the selected text, or nil to use the default.
+	Highlight *color.NRGBA
+
+	// Foreground color of the selected text, or nil to use the default.
+	HighlightText *color.NRGBA
+
+	// Color of the bold text, or nil to use the default.
+	Bold *color.NRGBA
+
+	// Color palette. It contains 0, 8, 16, 232, or 256 colors.
+	Palette []color.NRGBA
+}
+
+// Validate checks that the theme can be applied to the terminal.
+func (t *Theme) Validate() error {
+	if err := ValidatePaletteSize(len(t.Palette)); err != nil {
+		return fmt.Errorf("theme: %w", err)
+	}
+	return nil
+}
+
+// ValidatePaletteSize checks that the palette of n colors can be applied to
+// the terminal, i.e. that it contains 0, 8, 16, 232, or 256 colors.
+func ValidatePaletteSize(n int) error {
+	switch n {
+	case 0, 8, 16, 232, 256:
+		return nil
+	}
+	return fmt.Errorf("palette must contain 0, 8, 16, 232, or 256 colors, got %d", n)
+}
+
+// ErrUnknownFormat is returned by [Load] if format of the scheme file cannot be
+// determined.
+var ErrUnknownFormat = errors.New("theme: unknown scheme format")
+
+// Load reads a color scheme from the file. Format of the scheme is determined
+// by the file extension:
+//
+//   - .itermcolors: iTerm2 color preset, see [ParseITerm2].
+//   - .toml: Alacritty configuration, see [ParseAlacrittyTOML].
+//   - .yaml, .yml: Alacritty configuration or base16 scheme, see
+//     [ParseAlacrittyYAML] and [ParseBase16].
+//   - .json: Windows Terminal color scheme, see [ParseWindowsTerminal].
+//   - .Xresources, .Xdefaults, .xrdb: X resources, see [ParseXresources].
+//
+// If the scheme does not specify its name, the name of the file without
+// extension is used.
+func Load(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		base  = filepath.Base(path)
+		ext   = filepath.Ext(base)
+		theme *Theme
+		r     = bytes.NewReader(data)
+	)
+
+	switch strings.ToLower(ext) {
+	case ".itermcolors":
+		theme, err = ParseITerm2(r)
+	case ".toml":
+		theme, err = ParseAlacrittyTOML(r)
+	case ".yaml", ".yml":
+		if isBase16(data) {
+			theme, err = ParseBase16(r)
+		} else {
+			theme, err = ParseAlacrittyYAML(r)
+		}
+	case ".json":
+		theme, err = ParseWindowsTerminal(r)
+	case ".xresources", ".xdefaults", ".xrdb":
+		theme, err = ParseXresources(r)
+	default:
+		if base != ".Xresources" && base != ".Xdefaults" {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+		}
+		theme, err = ParseXresources(r)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("theme: %s: %w", path, err)
+	}
+
+	if theme.Name == "" && !strings.HasPrefix(base, ".") {
+		theme.Name = strings.TrimSuffix(base, ext)
+	}
+
+	return theme, nil
+}
+
+// palette collects colors with known indices into a palette. The palette
+// contains 16 colors, or 256 colors if any of indices is greater than 15.
+// Missing colors are filled with the xterm defaults.
+type palette map[int]color.NRGBA
+
+func (p palette) build() []color.NRGBA {
+	if len(p) == 0 {
+		return nil
+	}
+
+	n := 16
+	for i := range p {
+		if i >= 16 {
+			n = 256
+		}
+	}
+
+	colors := make([]color.NRGBA, n)
+	for i := range colors {
+		if c, ok := p[i]; ok {
+			colors[i] = c
+		} else {
+			colors[i] = xtermColor(i)
+		}
+	}
+
+	return colors
+}
@@ -0,0 +1,93 @@
+package theme_test
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func rgb(r, g, b uint8) color.NRGBA {
+	return color.NRGBA{r, g, b, 0xff}
+}
+
+func TestParseColor(t *testing.T) {
+	cases := map[string]color.NRGBA{
+		"#fff":               rgb(0xff, 0xff, 0xff),
+		"#1d1f21":            rgb(0x1d, 0x1f, 0x21),
+		"#1D1F21":            rgb(0x1d, 0x1f, 0x21),
+		"#ffff00000000":      rgb(0xff, 0x00, 0x00),
+		"0x373b41":           rgb(0x37, 0x3b, 0x41),
+		"rgb:ff/80/00":       rgb(0xff, 0x80, 0x00),
+		"rgb:f/8/0":          rgb(0xff, 0x88, 0x00),
+		"rgb:ffff/0000/ffff": rgb(0xff, 0x00, 0xff),
+		"  #000000\t":        rgb(0x00, 0x00, 0x00),
+	}
+
+	for s, expected := range cases {
+		c, err := theme.ParseColor(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, c, s)
+	}
+
+	for _, s := range []string{"", "#", "#ff", "#gggggg", "0xfff", "rgb:ff/ff", "rgb:fffff/0/0", "red"} {
+		_, err := theme.ParseColor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestTheme_Validate(t *testing.T) {
+	for _, n := range []int{0, 8, 16, 232, 256} {
+		th := theme.Theme{Palette: make([]color.NRGBA, n)}
+		assert.NoError(t, th.Validate())
+	}
+
+	th := theme.Theme{Palette: make([]color.NRGBA, 17)}
+	assert.Error(t, th.Validate())
+}
+
+func TestLoad(t *testing.T) {
+	cases := []struct {
+		file       string
+		name       string
+		foreground color.NRGBA
+		background color.NRGBA
+		palette    int
+	}{
+		{"tomorrow.itermcolors", "tomorrow", rgb(0xc5, 0xc8, 0xc6), rgb(0x1d, 0x1f, 0x21), 16},
+		{".Xresources", "", rgb(0xc5, 0xc8, 0xc6), color.NRGBA{0x1d, 0x1f, 0x21, 0xe6}, 256},
+		{"tomorrow.toml", "tomorrow", rgb(0xc5, 0xc8, 0xc6), rgb(0x1d, 0x1f, 0x21), 256},
+		{"tomorrow.yml", "tomorrow", rgb(0xc5, 0xc8, 0xc6), rgb(0x1d, 0x1f, 0x21), 16},
+		{"campbell.json", "Campbell", rgb(0xcc, 0xcc, 0xcc), rgb(0x0c, 0x0c, 0x0c), 16},
+		{"base16-tomorrow-night.yaml", "Tomorrow Night", rgb(0xc5, 0xc8, 0xc6), rgb(0x1d, 0x1f, 0x21), 16},
+		{"tinted-tomorrow.yaml", "Tinted Tomorrow", rgb(0xc5, 0xc8, 0xc6), rgb(0x1d, 0x1f, 0x21), 16},
+	}
+
+	for _, c := range cases {
+		t.Run(c.file, func(t *testing.T) {
+			th, err := theme.Load(filepath.Join("testdata", c.file))
+			assert.NoError(t, err)
+			assert.Equal(t, c.name, th.Name)
+			assert.Equal(t, c.foreground, th.Foreground)
+			assert.Equal(t, c.background, th.Background)
+			assert.Len(t, th.Palette, c.palette)
+			assert.NoError(t, th.Validate())
+		})
+	}
+
+	t.Run("Unknown format", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "scheme.conf")
+		assert.NoError(t, os.WriteFile(path, nil, 0o644))
+
+		_, err := theme.Load(path)
+		assert.ErrorIs(t, err, theme.ErrUnknownFormat)
+	})
+
+	t.Run("Nonexistent file", func(t *testing.T) {
+		_, err := theme.Load(filepath.Join("testdata", "nonexistent.toml"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
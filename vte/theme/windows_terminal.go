@@ -0,0 +1,106 @@
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// windowsTerminalScheme is a color scheme of Windows Terminal.
+type windowsTerminalScheme struct {
+	Name                string `json:"name"`
+	Foreground          string `json:"foreground"`
+	Background          string `json:"background"`
+	CursorColor         string `json:"cursorColor"`
+	SelectionBackground string `json:"selectionBackground"`
+
+	Black        string `json:"black"`
+	Red          string `json:"red"`
+	Green        string `json:"green"`
+	Yellow       string `json:"yellow"`
+	Blue         string `json:"blue"`
+	Purple       string `json:"purple"`
+	Cyan         string `json:"cyan"`
+	White        string `json:"white"`
+	BrightBlack  string `json:"brightBlack"`
+	BrightRed    string `json:"brightRed"`
+	BrightGreen  string `json:"brightGreen"`
+	BrightYellow string `json:"brightYellow"`
+	BrightBlue   string `json:"brightBlue"`
+	BrightPurple string `json:"brightPurple"`
+	BrightCyan   string `json:"brightCyan"`
+	BrightWhite  string `json:"brightWhite"`
+}
+
+// ParseWindowsTerminal parses Windows Terminal color scheme, i.e. a JSON
+// object from the "schemes" list of Windows Terminal settings.
+func ParseWindowsTerminal(r io.Reader) (*Theme, error) {
+	var scheme windowsTerminalScheme
+
+	if err := json.NewDecoder(r).Decode(&scheme); err != nil {
+		return nil, err
+	}
+
+	if scheme.Foreground == "" || scheme.Background == "" {
+		return nil, errors.New("Windows Terminal scheme must define foreground and background colors")
+	}
+
+	var (
+		theme = Theme{Name: scheme.Name}
+		err   error
+	)
+
+	if theme.Foreground, err = ParseColor(scheme.Foreground); err != nil {
+		return nil, fmt.Errorf("foreground: %w", err)
+	}
+	if theme.Background, err = ParseColor(scheme.Background); err != nil {
+		return nil, fmt.Errorf("background: %w", err)
+	}
+
+	optional := []struct {
+		name   string
+		value  string
+		target **color.NRGBA
+	}{
+		{"cursorColor", scheme.CursorColor, &theme.Cursor},
+		{"selectionBackground", scheme.SelectionBackground, &theme.Highlight},
+	}
+
+	for _, o := range optional {
+		if o.value == "" {
+			continue
+		}
+
+		c, err := ParseColor(o.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", o.name, err)
+		}
+		*o.target = &c
+	}
+
+	values := []string{
+		scheme.Black, scheme.Red, scheme.Green, scheme.Yellow,
+		scheme.Blue, scheme.Purple, scheme.Cyan, scheme.White,
+		scheme.BrightBlack, scheme.BrightRed, scheme.BrightGreen, scheme.BrightYellow,
+		scheme.BrightBlue, scheme.BrightPurple, scheme.BrightCyan, scheme.BrightWhite,
+	}
+
+	p := make(palette)
+
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+
+		c, err := ParseColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("color %d: %w", i, err)
+		}
+		p[i] = c
+	}
+
+	theme.Palette = p.build()
+	return &theme, nil
+}
@@ -0,0 +1,39 @@
+package theme_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWindowsTerminal(t *testing.T) {
+	f, err := os.Open("testdata/campbell.json")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	th, err := theme.ParseWindowsTerminal(f)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Campbell", th.Name)
+	assert.Equal(t, rgb(0xff, 0xff, 0xff), *th.Cursor)
+	assert.Equal(t, rgb(0xff, 0xff, 0xff), *th.Highlight)
+
+	assert.Len(t, th.Palette, 16)
+	assert.Equal(t, rgb(0x88, 0x17, 0x98), th.Palette[5])
+	assert.Equal(t, rgb(0xf2, 0xf2, 0xf2), th.Palette[15])
+
+	t.Run("Invalid scheme", func(t *testing.T) {
+		for _, s := range []string{
+			"[]",
+			`{"foreground": "#fff"}`,
+			`{"foreground": "#fff", "background": "#000", "red": "red"}`,
+			`{"foreground": "#fff", "background": "#000", "cursorColor": "white"}`,
+		} {
+			_, err := theme.ParseWindowsTerminal(strings.NewReader(s))
+			assert.Error(t, err, s)
+		}
+	})
+}
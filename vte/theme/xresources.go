@@ -0,0 +1,155 @@
+package theme
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseXresources parses color scheme defined with X resources, e.g.
+//
+//	#define bg #1d1f21
+//	*.background: bg
+//	*.foreground: #c5c8c6
+//	URxvt*color0: rgb:1d/1f/21
+//
+// Only the last component of the resource name is taken into account, so
+// both "*color0" and "URxvt.color0" define color 0. Simple #define macros are
+// expanded, other preprocessor directives are ignored.
+func ParseXresources(r io.Reader) (*Theme, error) {
+	var (
+		theme   Theme
+		colors  = make(palette)
+		defines = make(map[string]string)
+		scanner = bufio.NewScanner(r)
+		hasFg   bool
+		hasBg   bool
+		lineNo  int
+	)
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		if directive, ok := strings.CutPrefix(line, "#"); ok {
+			fields := strings.Fields(directive)
+			if len(fields) == 3 && fields[0] == "define" {
+				defines[fields[1]] = fields[2]
+			}
+			continue
+		}
+
+		// Lines that are not resource definitions, e.g. continuation of a
+		// multiline value, are ignored.
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		if i := strings.LastIndexAny(name, ".*"); i >= 0 {
+			name = name[i+1:]
+		}
+
+		value = strings.TrimSpace(value)
+		if v, ok := defines[value]; ok {
+			value = v
+		}
+
+		var target *color.NRGBA
+
+		switch strings.ToLower(name) {
+		case "foreground":
+			target = &theme.Foreground
+			hasFg = true
+		case "background":
+			target = &theme.Background
+			hasBg = true
+		case "cursorcolor":
+			theme.Cursor = new(color.NRGBA)
+			target = theme.Cursor
+		case "cursorcolor2":
+			theme.CursorText = new(color.NRGBA)
+			target = theme.CursorText
+		case "highlightcolor":
+			theme.Highlight = new(color.NRGBA)
+			target = theme.Highlight
+		case "highlighttextcolor":
+			theme.HighlightText = new(color.NRGBA)
+			target = theme.HighlightText
+		case "colorbd":
+			theme.Bold = new(color.NRGBA)
+			target = theme.Bold
+		default:
+			index, ok := strings.CutPrefix(name, "color")
+			if !ok {
+				continue
+			}
+
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 || i > 255 {
+				continue
+			}
+
+			c, err := parseXresourcesColor(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			colors[i] = c
+			continue
+		}
+
+		c, err := parseXresourcesColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		*target = c
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !hasFg || !hasBg {
+		return nil, errors.New("X resources must define foreground and background colors")
+	}
+
+	theme.Palette = colors.build()
+	return &theme, nil
+}
+
+// parseXresourcesColor parses color optionally prefixed with opacity in
+// percent, e.g. "[90]#1d1f21", as used by rxvt-unicode.
+func parseXresourcesColor(s string) (color.NRGBA, error) {
+	opacity := 100
+
+	if rest, ok := strings.CutPrefix(s, "["); ok {
+		percent, c, ok := strings.Cut(rest, "]")
+		if !ok {
+			return color.NRGBA{}, fmt.Errorf("theme: invalid color %q", s)
+		}
+
+		v, err := strconv.Atoi(percent)
+		if err != nil || v < 0 || v > 100 {
+			return color.NRGBA{}, fmt.Errorf("theme: invalid color %q", s)
+		}
+
+		opacity, s = v, c
+	}
+
+	c, err := ParseColor(s)
+	if err != nil {
+		return color.NRGBA{}, err
+	}
+
+	c.A = uint8((opacity*0xff + 50) / 100)
+	return c, nil
+}
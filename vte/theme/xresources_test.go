@@ -0,0 +1,45 @@
+package theme_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseXresources(t *testing.T) {
+	f, err := os.Open("testdata/.Xresources")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	th, err := theme.ParseXresources(f)
+	assert.NoError(t, err)
+
+	assert.Equal(t, rgb(0xc5, 0xc8, 0xc6), th.Foreground)
+	assert.Equal(t, rgb(0xff, 0xff, 0xff), *th.Cursor)
+
+	assert.Len(t, th.Palette, 256)
+	assert.Equal(t, rgb(0x00, 0x00, 0x00), th.Palette[0])
+	assert.Equal(t, rgb(0xcc, 0x66, 0x66), th.Palette[1])
+	assert.Equal(t, rgb(0x88, 0xaa, 0xff), th.Palette[12])
+	assert.Equal(t, rgb(0x12, 0x34, 0x56), th.Palette[100])
+
+	// Colors missing from the scheme are xterm defaults.
+	assert.Equal(t, rgb(0x00, 0xcd, 0x00), th.Palette[2])
+	assert.Equal(t, rgb(0x00, 0x00, 0x00), th.Palette[16])
+	assert.Equal(t, rgb(0x5f, 0x87, 0xaf), th.Palette[67])
+	assert.Equal(t, rgb(0xee, 0xee, 0xee), th.Palette[255])
+
+	t.Run("Invalid scheme", func(t *testing.T) {
+		for _, s := range []string{
+			"*.foreground: #fff",
+			"*.foreground: #fff\n*.background: black",
+			"*.foreground: #fff\n*.background: #000\n*.color1: [200]#fff",
+		} {
+			_, err := theme.ParseXresources(strings.NewReader(s))
+			assert.Error(t, err, s)
+		}
+	})
+}
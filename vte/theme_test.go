@@ -0,0 +1,41 @@
+package vte_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/shelepuginivan/gotk3-vte/vte/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminal_ApplyTheme(t *testing.T) {
+	term := newTerm(t)
+
+	th, err := theme.Load("theme/testdata/campbell.json")
+	assert.NoError(t, err)
+	assert.NoError(t, term.ApplyTheme(th))
+
+	feed(term, "\x1b[31mred\x1b[0m")
+
+	snapshot, err := term.Snapshot()
+	assert.NoError(t, err)
+
+	fg := snapshot.Rows[0][0].Foreground
+	assert.NotNil(t, fg)
+	assert.InDelta(t, float64(0xc5)/0xff, fg.GetRed(), 0.01)
+	assert.InDelta(t, float64(0x0f)/0xff, fg.GetGreen(), 0.01)
+	assert.InDelta(t, float64(0x1f)/0xff, fg.GetBlue(), 0.01)
+
+	t.Run("Without optional colors", func(t *testing.T) {
+		assert.NoError(t, term.ApplyTheme(&theme.Theme{
+			Foreground: color.NRGBA{0xff, 0xff, 0xff, 0xff},
+			Background: color.NRGBA{0x00, 0x00, 0x00, 0xff},
+		}))
+	})
+
+	t.Run("Invalid palette", func(t *testing.T) {
+		assert.Error(t, term.ApplyTheme(&theme.Theme{
+			Palette: make([]color.NRGBA, 3),
+		}))
+	})
+}